@@ -1,15 +1,19 @@
 package sham
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
+	"strconv"
 )
 
 // Node represents a single element in the abstract syntax tree. A valid Sham
 // AST must be able to generate data. As such, every node in tree must be
 // implement the Generator interface. There are two main type of nodes: structural
 // and terminal. Terminal nodes are leaves that generate values. Structural nodes
-// generate the data structures that hold these values.
+// generate the data structures that hold these values. Nodes that also
+// implement RandGenerator draw their random decisions from the source passed
+// to Schema.GenerateRand; other nodes use the global source.
 type Node interface {
 	Generator
 }
@@ -18,19 +22,146 @@ type Node interface {
 // AST must either be a single terminal node, or a structural node.
 type Schema struct {
 	Root Node
+	// Locale is the locale the schema was parsed with, if any.
+	Locale string
 }
 
 // Generate triggers the Sham data generation process. The generation process
 // begins with the root and walks the tree, generating data structures and data
-// as it goes. Data generation cannot cause errors. Any possible errors will
-// have been caught during the tokenization and parsing processes. This method
-// can be called more than once to generate more data using the same schema.
+// as it goes. Errors in the schema will have been caught during the
+// tokenization and parsing processes, but terminal generators implementing
+// ErrGenerator panic if they fail; use GenerateE to receive their errors. This
+// method can be called more than once to generate more data using the same
+// schema.
 func (s Schema) Generate() interface{} {
+	return s.GenerateRand(globalRand)
+}
+
+// GenerateRand performs a generation using the provided source for every
+// random decision. Generating with identically seeded sources produces
+// identical data, provided every terminal generator implements RandGenerator.
+func (s Schema) GenerateRand(r *rand.Rand) interface{} {
 	if s.Root == nil {
 		return nil
 	}
 
-	return s.Root.Generate()
+	return generateRand(s.Root, r)
+}
+
+// GenerateError describes a failed generation. The path identifies the node
+// that failed within the generated data, such as $.friends[3].phone.
+type GenerateError struct {
+	Path string
+	Err  error
+}
+
+func (e *GenerateError) Error() string {
+	return fmt.Sprintf("sham: cannot generate %s: %v", e.Path, e.Err)
+}
+
+func (e *GenerateError) Unwrap() error { return e.Err }
+
+// GenerateE performs a generation like GenerateRand, but reports failures
+// instead of panicking. Terminal generators implementing ErrGenerator receive
+// the context and may return errors, and terminal generators registered as nil
+// are reported rather than dereferenced. Generation stops once the context is
+// done. Any failure is returned as a *GenerateError holding the path of the
+// failing node.
+func (s Schema) GenerateE(ctx context.Context, r *rand.Rand) (interface{}, error) {
+	if s.Root == nil {
+		return nil, nil
+	}
+
+	return generateNode(ctx, s.Root, r, "$")
+}
+
+// GenerateContext performs a generation using the global source, stopping
+// early if the context is cancelled or its deadline passes. Cancellation is
+// checked before each node is generated, and is reported as a *GenerateError
+// wrapping the context's error.
+func (s Schema) GenerateContext(ctx context.Context) (interface{}, error) {
+	return s.GenerateE(ctx, globalRand)
+}
+
+// generateNode walks the tree below n, tracking the path of each node so that
+// failures can be located. Nodes defined outside of this package are treated
+// as leaves.
+func generateNode(ctx context.Context, n Node, r *rand.Rand, path string) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, &GenerateError{Path: path, Err: err}
+	}
+
+	switch n := n.(type) {
+	case Object:
+		out := NewOrderedMap()
+		for _, kv := range n.Values {
+			if kv.Optional && r.Intn(2) == 0 {
+				continue
+			}
+
+			v, err := generateNode(ctx, kv.Value, r, path+"."+kv.Key)
+			if err != nil {
+				return nil, err
+			}
+			out.Set(kv.Key, v)
+		}
+		return out, nil
+	case Array:
+		if n.Inner == nil {
+			return []interface{}{}, nil
+		}
+
+		size := 1
+		if n.Range != nil {
+			size = n.Range.GetValueRand(r)
+		}
+
+		out := make([]interface{}, size)
+		for i := range out {
+			v, err := generateNode(ctx, n.Inner, r, path+"["+strconv.Itoa(i)+"]")
+			if err != nil {
+				return nil, err
+			}
+			out[i] = v
+		}
+		return out, nil
+	case Choice:
+		if len(n.Options) == 0 {
+			return nil, nil
+		}
+		return generateNode(ctx, n.Options[r.Intn(len(n.Options))], r, path)
+	case FormattedString:
+		if len(n.Params) == 0 {
+			return n.Raw, nil
+		}
+
+		params := make([]interface{}, len(n.Params))
+		for i, p := range n.Params {
+			v, err := generateE(ctx, p, r)
+			if err != nil {
+				return nil, &GenerateError{Path: path, Err: err}
+			}
+			params[i] = v
+		}
+		return fmt.Sprintf(n.Format, params...), nil
+	case TerminalGenerator:
+		if n.fn == nil {
+			return nil, &GenerateError{Path: path, Err: fmt.Errorf("terminal generator %q is nil", n.Name)}
+		}
+		v, err := generateE(ctx, n.fn, r)
+		if err != nil {
+			return nil, &GenerateError{Path: path, Err: err}
+		}
+		return v, nil
+	case ErrGenerator:
+		v, err := n.GenerateE(ctx, r)
+		if err != nil {
+			return nil, &GenerateError{Path: path, Err: err}
+		}
+		return v, nil
+	}
+
+	return generateRand(n, r), nil
 }
 
 // Object represents a key-value data structure. In order to maintain the key
@@ -41,10 +172,12 @@ type Object struct {
 	Values []KV
 }
 
-// KV represents a single key-value pair in an Object.
+// KV represents a single key-value pair in an Object. An optional pair is
+// only present in half of the generated objects.
 type KV struct {
-	Key   string
-	Value Node
+	Key      string
+	Value    Node
+	Optional bool
 }
 
 // AppendPair adds a key-value pair to an Object.
@@ -52,13 +185,25 @@ func (m *Object) AppendPair(k string, v Node) {
 	m.Values = append(m.Values, KV{Key: k, Value: v})
 }
 
+// AppendOptionalPair adds an optional key-value pair to an Object.
+func (m *Object) AppendOptionalPair(k string, v Node) {
+	m.Values = append(m.Values, KV{Key: k, Value: v, Optional: true})
+}
+
 // Generate creates a map of key-value pairs from the slice of KVs. An ordered
 // map is used to preserve the order of the provided keys. If the same key is
-// provided multiple times, then only the last value will be used.
-func (m Object) Generate() interface{} {
+// provided multiple times, then only the last value will be used. Optional
+// pairs are randomly omitted.
+func (m Object) Generate() interface{} { return m.GenerateRand(globalRand) }
+
+// GenerateRand creates a map of key-value pairs using the provided source.
+func (m Object) GenerateRand(r *rand.Rand) interface{} {
 	out := NewOrderedMap()
 	for _, kv := range m.Values {
-		out.Set(kv.Key, kv.Value.Generate())
+		if kv.Optional && r.Intn(2) == 0 {
+			continue
+		}
+		out.Set(kv.Key, generateRand(kv.Value, r))
 	}
 	return out
 }
@@ -77,23 +222,45 @@ type Array struct {
 // the inner node field. If the range is omitted, then exactly one element will
 // populate the array. Otherwise, a random number of elements will be generated
 // based on the inclusive range of integers.
-func (a Array) Generate() interface{} {
+func (a Array) Generate() interface{} { return a.GenerateRand(globalRand) }
+
+// GenerateRand creates a slice of generated values using the provided source.
+func (a Array) GenerateRand(r *rand.Rand) interface{} {
 	if a.Inner == nil {
 		return []interface{}{}
 	}
 
 	n := 1
 	if a.Range != nil {
-		n = a.Range.GetValue()
+		n = a.Range.GetValueRand(r)
 	}
 
 	out := make([]interface{}, n)
 	for i := 0; i < n; i++ {
-		out[i] = a.Inner.Generate()
+		out[i] = generateRand(a.Inner, r)
 	}
 	return out
 }
 
+// Choice represents a set of alternative values. In the Sham language, the
+// alternatives are separated by the "|" character. Each generation picks one
+// of the options uniformly at random.
+type Choice struct {
+	Options []Node
+}
+
+// Generate picks a random option and generates its value.
+func (c Choice) Generate() interface{} { return c.GenerateRand(globalRand) }
+
+// GenerateRand picks a random option using the provided source.
+func (c Choice) GenerateRand(r *rand.Rand) interface{} {
+	if len(c.Options) == 0 {
+		return nil
+	}
+
+	return generateRand(c.Options[r.Intn(len(c.Options))], r)
+}
+
 // Range is an inclusive range of integers. Ranges have two uses within the a
 // Sham schema. If provided as the first argument in an array, the range will
 // be used to determine the number of elements to populate the array. If provided
@@ -107,17 +274,25 @@ type Range struct {
 // GetValue retrieves a random integer from the inclusive range [min, max]. The
 // chosen integer is not cryptographically secure and should never be treated
 // as such.
-func (r Range) GetValue() int {
+func (r Range) GetValue() int { return r.GetValueRand(globalRand) }
+
+// GetValueRand retrieves a random integer from the inclusive range [min, max]
+// using the provided source.
+func (r Range) GetValueRand(rnd *rand.Rand) int {
 	if r.Min == r.Max {
 		return r.Min
 	}
 
-	return rand.Intn((r.Max+1)-r.Min) + r.Min
+	return rnd.Intn((r.Max+1)-r.Min) + r.Min
 }
 
 // Generate chooses a random integer from the inclusive range.
 func (r Range) Generate() interface{} { return r.GetValue() }
 
+// GenerateRand chooses a random integer from the inclusive range using the
+// provided source.
+func (r Range) GenerateRand(rnd *rand.Rand) interface{} { return r.GetValueRand(rnd) }
+
 // FormattedString represents a string literal with values that can be interpolated
 // into the string. In the Sham language, formatted strings are enclosed in
 // backticks, and the interpolated values are enclosed by curly braces. Interpolated
@@ -130,31 +305,56 @@ type FormattedString struct {
 
 // Generate produces a string literal value by replacing interpolated values with
 // the values generated by the corresponding terminal generator.
-func (f FormattedString) Generate() interface{} {
+func (f FormattedString) Generate() interface{} { return f.GenerateRand(globalRand) }
+
+// GenerateRand produces a string literal value using the provided source for
+// the interpolated values.
+func (f FormattedString) GenerateRand(r *rand.Rand) interface{} {
 	if len(f.Params) == 0 {
 		return f.Raw
 	}
 
 	params := make([]interface{}, len(f.Params))
 	for i, p := range f.Params {
-		params[i] = p.Generate()
+		params[i] = generateRand(p, r)
 	}
 	return fmt.Sprintf(f.Format, params...)
 }
 
-// TerminalGenerator represents a function that can generate data.
+// ParamNames returns the names of the terminal generators interpolated into
+// the string, in the order they appear.
+func (f FormattedString) ParamNames() []string {
+	matches := fStringRegex.FindAllStringSubmatch(f.Raw, -1)
+	names := make([]string, len(matches))
+	for i, m := range matches {
+		names[i] = m[1]
+	}
+	return names
+}
+
+// TerminalGenerator represents a function that can generate data. Args holds
+// the arguments the generator was given in the schema, if any.
 type TerminalGenerator struct {
 	Name string
+	Args []interface{}
 	fn   Generator
 }
 
 // Generate runs the terminal generator's generation function. The generator is
 // expected to be a non nil interface. If nil was registered for this terminal
-// generator, then this method will panic.
+// generator, then this method will panic. Use Schema.GenerateE to report the
+// failure as an error instead.
 func (t TerminalGenerator) Generate() interface{} {
 	return t.fn.Generate()
 }
 
+// GenerateRand runs the terminal generator's generation function with the
+// provided source. Generators that do not implement RandGenerator use the
+// global source instead.
+func (t TerminalGenerator) GenerateRand(r *rand.Rand) interface{} {
+	return generateRand(t.fn, r)
+}
+
 // Literal represents a literal value. No data generation is involved here, but
 // rather values are returned as-is.
 type Literal struct {
@@ -163,3 +363,6 @@ type Literal struct {
 
 // Generate returns the literal value.
 func (l Literal) Generate() interface{} { return l.Value }
+
+// GenerateRand returns the literal value.
+func (l Literal) GenerateRand(*rand.Rand) interface{} { return l.Value }
@@ -0,0 +1,135 @@
+package sham
+
+import (
+	"context"
+	"io/ioutil"
+	"math/rand"
+	"testing"
+)
+
+func BenchmarkTokenize(b *testing.B) {
+	src := []byte(benchmarkSchema)
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(src)))
+	for i := 0; i < b.N; i++ {
+		if _, err := Tokenize(src); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	src := []byte(benchmarkSchema)
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(src)))
+	for i := 0; i < b.N; i++ {
+		if _, err := NewDefaultParser(src).Parse(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// benchmarkNodes holds a schema for each type of node, measured both by the
+// schema's own generation and by the compiled program.
+var benchmarkNodes = []struct {
+	name   string
+	schema string
+}{
+	{name: "Object", schema: `{"a": 1, "b": 2, "c": 3, "d": 4}`},
+	{name: "OptionalKeys", schema: `{"a"?: 1, "b"?: 2, "c"?: 3, "d"?: 4}`},
+	{name: "Array", schema: `[(10,10), 1]`},
+	{name: "Choice", schema: `"a" | "b" | "c" | "d"`},
+	{name: "Range", schema: `(0,1000)`},
+	{name: "Literal", schema: `"literal"`},
+	{name: "FormattedString", schema: "`{firstName}.{lastName}@example.com`"},
+	{name: "TerminalGenerator", schema: `name`},
+	{name: "Regex", schema: `/[a-f0-9]{8}-[a-f0-9]{4}-[a-f0-9]{4}/`},
+	{name: "RegexRepetition", schema: `/(ab|cd)+x*y?/`},
+}
+
+func BenchmarkNode(b *testing.B) {
+	for _, n := range benchmarkNodes {
+		s, err := NewDefaultParser([]byte(n.schema)).Parse()
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(n.name, func(b *testing.B) {
+			r := rand.New(rand.NewSource(1))
+
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				s.GenerateRand(r)
+			}
+		})
+	}
+}
+
+func BenchmarkProgram_Node(b *testing.B) {
+	for _, n := range benchmarkNodes {
+		s, err := NewDefaultParser([]byte(n.schema)).Parse()
+		if err != nil {
+			b.Fatal(err)
+		}
+		p := s.Compile()
+
+		b.Run(n.name, func(b *testing.B) {
+			r := rand.New(rand.NewSource(1))
+
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				p.GenerateRand(r)
+			}
+		})
+
+		b.Run(n.name+"/JSON", func(b *testing.B) {
+			r := rand.New(rand.NewSource(1))
+
+			var buf []byte
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				buf, _ = p.AppendJSON(buf[:0], r)
+			}
+		})
+	}
+}
+
+func BenchmarkEncoder(b *testing.B) {
+	p := benchmarkParse(b).Compile()
+
+	for _, f := range []Format{FormatJSON, FormatXML} {
+		for _, indent := range []string{"", "    "} {
+			name := string(f)
+			if indent != "" {
+				name += "-indent"
+			}
+
+			b.Run(name, func(b *testing.B) {
+				w := &countingWriter{}
+				e := NewEncoder(w, f)
+				e.Indent = indent
+				e.Rand = rand.New(rand.NewSource(1))
+
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if err := e.EncodeProgram(context.Background(), p); err != nil {
+						b.Fatal(err)
+					}
+				}
+				b.SetBytes(w.n / int64(b.N))
+			})
+		}
+	}
+}
+
+// countingWriter discards its input while counting the bytes written.
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return ioutil.Discard.Write(p)
+}
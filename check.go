@@ -0,0 +1,281 @@
+package sham
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+const (
+	// defaultCheckRuns is the number of values tested by a Checker that does
+	// not define Runs.
+	defaultCheckRuns = 100
+	// defaultMaxShrinks is the number of shrinking attempts made by a Checker
+	// that does not define MaxShrinks.
+	defaultMaxShrinks = 1000
+)
+
+// CheckError is returned by Check when a generated value fails the property.
+// The value is shrunk before being reported, so Value is the smallest failing
+// value found while Original is the value that first failed.
+type CheckError struct {
+	// Value is the smallest value found that fails the property.
+	Value interface{}
+	// Err is the error returned by the property for Value.
+	Err error
+	// Original is the first generated value that failed the property.
+	Original interface{}
+	// Seed is the seed of the run, which can be provided to a Checker to
+	// reproduce the failure.
+	Seed int64
+	// Runs is the number of values tested until the failure was found.
+	Runs int
+	// Shrinks is the number of successful shrinking steps.
+	Shrinks int
+}
+
+func (e *CheckError) Error() string {
+	d, err := json.Marshal(e.Value)
+	if err != nil {
+		d = []byte(fmt.Sprint(e.Value))
+	}
+	return fmt.Sprintf("sham: property failed after %d runs (seed %d, %d shrinks): %v\nvalue: %s", e.Runs, e.Seed, e.Shrinks, e.Err, d)
+}
+
+func (e *CheckError) Unwrap() error { return e.Err }
+
+// Checker tests properties against values generated from a schema. The zero
+// value is ready to use with default settings.
+type Checker struct {
+	// Runs is the number of values to test. Defaults to 100.
+	Runs int
+	// Seed seeds the generated values. If zero, a seed is chosen based on the
+	// current time and reported in any CheckError.
+	Seed int64
+	// MaxShrinks bounds the number of attempts made to shrink a failing value.
+	// Defaults to 1000.
+	MaxShrinks int
+}
+
+// Check tests a property against values generated from the schema using a
+// Checker with default settings.
+func Check(s Schema, prop func(v interface{}) error) error {
+	return Checker{}.Check(s, prop)
+}
+
+// Check tests a property against values generated from the schema. The
+// property fails if it returns an error or panics. On failure, the random
+// decisions made while generating the value are shrunk to find the smallest
+// value that still fails: arrays with fewer elements, ranges closer to their
+// minimum, regular expressions with fewer repetitions and earlier
+// alternatives, and choices of earlier options. The result is returned as a
+// *CheckError.
+//
+// Shrinking replays the decisions through the schema's terminal generators,
+// so only generators implementing RandGenerator shrink.
+func (c Checker) Check(s Schema, prop func(v interface{}) error) error {
+	if c.Runs <= 0 {
+		c.Runs = defaultCheckRuns
+	}
+	if c.MaxShrinks <= 0 {
+		c.MaxShrinks = defaultMaxShrinks
+	}
+	if c.Seed == 0 {
+		c.Seed = time.Now().UnixNano()
+	}
+
+	src := rand.NewSource(c.Seed)
+	for i := 1; i <= c.Runs; i++ {
+		cs := &choiceSource{rnd: src}
+		v := s.GenerateRand(rand.New(cs))
+
+		if err := runProperty(prop, v); err != nil {
+			sh := shrinker{schema: s, prop: prop, budget: c.MaxShrinks}
+			sh.shrink(cs.consumed(), v, err)
+
+			return &CheckError{
+				Value:    sh.value,
+				Err:      sh.err,
+				Original: v,
+				Seed:     c.Seed,
+				Runs:     i,
+				Shrinks:  sh.shrinks,
+			}
+		}
+	}
+
+	return nil
+}
+
+func runProperty(prop func(v interface{}) error, v interface{}) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	return prop(v)
+}
+
+// choiceSource is a rand.Source that replays a sequence of recorded choices.
+// Once the choices are exhausted, new choices are drawn from rnd and recorded,
+// or are zero if rnd is nil. Choices map onto values in the same way as
+// NewByteSource, so a zero choice is the smallest possible decision.
+type choiceSource struct {
+	choices []uint32
+	pos     int
+	rnd     rand.Source
+}
+
+func (s *choiceSource) Int63() int64 {
+	if s.pos == len(s.choices) {
+		var c uint32
+		if s.rnd != nil {
+			c = uint32(s.rnd.Int63() >> 31)
+		}
+		s.choices = append(s.choices, c)
+	}
+
+	c := s.choices[s.pos]
+	s.pos++
+	return int64(c>>1) << 32
+}
+
+func (s *choiceSource) Seed(int64) {}
+
+// consumed returns the choices used so far.
+func (s *choiceSource) consumed() []uint32 {
+	return s.choices[:s.pos]
+}
+
+// shrinker searches for a smaller sequence of choices that still fails the
+// property. A sequence is smaller if it is shorter, or if it is the same length
+// and lexicographically smaller.
+type shrinker struct {
+	schema  Schema
+	prop    func(v interface{}) error
+	budget  int
+	shrinks int
+
+	choices []uint32
+	value   interface{}
+	err     error
+}
+
+func (sh *shrinker) shrink(choices []uint32, v interface{}, err error) {
+	sh.choices, sh.value, sh.err = choices, v, err
+
+	for improved := true; improved && sh.budget > 0; {
+		improved = false
+		for _, pass := range []func() bool{sh.deleteChunks, sh.zeroChunks, sh.minimizeChoices} {
+			if pass() {
+				improved = true
+			}
+		}
+	}
+}
+
+// try generates a value from the candidate choices and keeps the candidate if
+// it is smaller and still fails the property.
+func (sh *shrinker) try(candidate []uint32) bool {
+	if sh.budget <= 0 {
+		return false
+	}
+	sh.budget--
+
+	cs := &choiceSource{choices: candidate}
+	v := sh.schema.GenerateRand(rand.New(cs))
+	candidate = cs.consumed()
+	if !shortlexLess(candidate, sh.choices) {
+		return false
+	}
+
+	err := runProperty(sh.prop, v)
+	if err == nil {
+		return false
+	}
+
+	sh.choices, sh.value, sh.err = candidate, v, err
+	sh.shrinks++
+	return true
+}
+
+// deleteChunks removes runs of consecutive choices, which typically removes
+// elements from arrays.
+func (sh *shrinker) deleteChunks() bool {
+	improved := false
+	for _, k := range []int{8, 4, 2, 1} {
+		for i := len(sh.choices) - k; i >= 0; i-- {
+			if i+k > len(sh.choices) {
+				continue
+			}
+
+			candidate := append(append([]uint32{}, sh.choices[:i]...), sh.choices[i+k:]...)
+			if sh.try(candidate) {
+				improved = true
+			}
+		}
+	}
+	return improved
+}
+
+// zeroChunks replaces runs of consecutive choices with zero, the smallest
+// decision.
+func (sh *shrinker) zeroChunks() bool {
+	improved := false
+	for _, k := range []int{8, 4, 2, 1} {
+		for i := 0; i+k <= len(sh.choices); i++ {
+			candidate := append([]uint32{}, sh.choices...)
+			zeroed := false
+			for j := i; j < i+k; j++ {
+				zeroed = zeroed || candidate[j] != 0
+				candidate[j] = 0
+			}
+
+			if zeroed && sh.try(candidate) {
+				improved = true
+			}
+		}
+	}
+	return improved
+}
+
+// minimizeChoices binary searches for the smallest value of each choice that
+// still fails the property.
+func (sh *shrinker) minimizeChoices() bool {
+	improved := false
+	for i := 0; i < len(sh.choices); i++ {
+		lo, hi := uint32(0), sh.choices[i]
+		for lo < hi && sh.budget > 0 {
+			mid := lo + (hi-lo)/2
+
+			candidate := append([]uint32{}, sh.choices...)
+			candidate[i] = mid
+
+			if sh.try(candidate) {
+				improved = true
+				if i >= len(sh.choices) {
+					break
+				}
+				hi = sh.choices[i]
+			} else {
+				lo = mid + 1
+			}
+		}
+	}
+	return improved
+}
+
+func shortlexLess(a, b []uint32) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
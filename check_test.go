@@ -0,0 +1,123 @@
+package sham
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestChecker_Check(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		prop   func(v interface{}) error
+		want   string
+	}{
+		{
+			name:   "Integers shrink toward the smallest failure",
+			schema: `(0,1000)`,
+			prop: func(v interface{}) error {
+				if v.(int) >= 50 {
+					return errors.New("too large")
+				}
+				return nil
+			},
+			want: `50`,
+		},
+		{
+			name:   "Arrays shrink toward fewer smaller elements",
+			schema: `[(0,10), (0,100)]`,
+			prop: func(v interface{}) error {
+				if len(v.([]interface{})) > 2 {
+					return errors.New("too long")
+				}
+				return nil
+			},
+			want: `[0,0,0]`,
+		},
+		{
+			name:   "Regexes shrink toward shorter strings and earlier alternatives",
+			schema: `/(foo|bar)[a-z]{0,10}/`,
+			prop: func(v interface{}) error {
+				if len(v.(string)) > 4 {
+					return errors.New("too long")
+				}
+				return nil
+			},
+			want: `"fooaa"`,
+		},
+		{
+			name:   "Choices and optional keys shrink",
+			schema: `{"a"?: (0,10), "b": "x" | "y" | "z", "c": [(1,5), (0,9)]}`,
+			prop: func(v interface{}) error {
+				if len(v.(*OrderedMap).Values["c"].([]interface{})) > 1 {
+					return errors.New("too many")
+				}
+				return nil
+			},
+			want: `{"b":"x","c":[0,0]}`,
+		},
+		{
+			name:   "Panics fail the property",
+			schema: `[(0,10), (0,100)]`,
+			prop: func(v interface{}) error {
+				for _, x := range v.([]interface{}) {
+					if x.(int) > 10 {
+						panic("out of range")
+					}
+				}
+				return nil
+			},
+			want: `[11]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewDefaultParser([]byte(tt.schema)).Parse()
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			err = Checker{Seed: 1}.Check(s, tt.prop)
+			var ce *CheckError
+			if !errors.As(err, &ce) {
+				t.Fatalf("Check() error = %v, want *CheckError", err)
+			}
+
+			got, err := json.Marshal(ce.Value)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Check() shrunk to %s, want %s (original %v)", got, tt.want, ce.Original)
+			}
+			if ce.Seed != 1 || !strings.Contains(ce.Error(), tt.want) {
+				t.Errorf("Check() error = %v", ce)
+			}
+		})
+	}
+}
+
+func TestChecker_Check_Passes(t *testing.T) {
+	s, err := NewDefaultParser([]byte(`[(0,10), (0,100)]`)).Parse()
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	runs := 0
+	err = Checker{Runs: 25}.Check(s, func(v interface{}) error {
+		runs++
+		if len(v.([]interface{})) > 10 {
+			return fmt.Errorf("length %d", len(v.([]interface{})))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Check() error = %v", err)
+	}
+	if runs != 25 {
+		t.Errorf("Check() ran %d times, want 25", runs)
+	}
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"runtime"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mattmeyers/sham"
+)
+
+// runBench implements the bench subcommand. The schema is read in the same way
+// as the main command, and the throughput of each way of generating it is
+// written to stdout. The top level fields of the schema are then measured on
+// their own to show which parts of the schema are expensive.
+func runBench(args []string) {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Println(`bench measures the cost of generating data from a sham schema
+
+Usage:
+
+	sham bench [options] <schema>
+
+Options:
+	-t duration	the time to spend on each measurement (default 1s)
+	-locale code	the locale of generated names, addresses and phone numbers
+	-wordlist name=path
+			register the word list file as the terminal generator name
+	-h, --help	show this help message`)
+	}
+	d := fs.Duration("t", time.Second, "the time to spend on each measurement")
+	fs.StringVar(&oLocale, "locale", "", "the locale of generated names, addresses and phone numbers")
+	fs.Var(oWordLists, "wordlist", "register a word list file as a terminal generator, given as name=path")
+	_ = fs.Parse(args)
+
+	s, err := loadSchema(fs.Args())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	tw := tabwriter.NewWriter(stdout, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "\tdocs/s\tMB/s\tallocs/doc\tB/doc\t")
+
+	p := s.Compile()
+	benchmarks := []struct {
+		name string
+		run  func(r *rand.Rand) (int, error)
+	}{
+		{name: "generate", run: func(r *rand.Rand) (int, error) { s.GenerateRand(r); return 0, nil }},
+		{name: "json", run: func(r *rand.Rand) (int, error) {
+			b, err := json.Marshal(s.GenerateRand(r))
+			return len(b), err
+		}},
+		{name: "compiled generate", run: func(r *rand.Rand) (int, error) { p.GenerateRand(r); return 0, nil }},
+		{name: "compiled json", run: func(r *rand.Rand) (int, error) {
+			b, err := p.AppendJSON(nil, r)
+			return len(b), err
+		}},
+		{name: "encoder json", run: encodeBenchmark(s, sham.FormatJSON)},
+		{name: "encoder xml", run: encodeBenchmark(s, sham.FormatXML)},
+	}
+	for _, b := range benchmarks {
+		res, err := measure(*d, b.run)
+		if err != nil {
+			log.Fatal(err)
+		}
+		res.write(tw, b.name)
+	}
+
+	for _, f := range benchFields(s) {
+		res, err := measure(*d, encodeBenchmark(f.schema, sham.FormatJSON))
+		if err != nil {
+			log.Fatal(err)
+		}
+		res.write(tw, f.path)
+	}
+
+	tw.Flush()
+}
+
+// encodeBenchmark returns a benchmark encoding the schema in the format.
+func encodeBenchmark(s sham.Schema, f sham.Format) func(r *rand.Rand) (int, error) {
+	w := &countingWriter{}
+	e := sham.NewEncoder(w, f)
+	p := s.Compile()
+
+	return func(r *rand.Rand) (int, error) {
+		w.n = 0
+		e.Rand = r
+		err := e.EncodeProgram(context.Background(), p)
+		return w.n, err
+	}
+}
+
+// benchField is a part of a schema measured on its own.
+type benchField struct {
+	path   string
+	schema sham.Schema
+}
+
+// benchFields lists the fields of the root object, or of the object held by
+// the root array.
+func benchFields(s sham.Schema) []benchField {
+	prefix := "$"
+	root := s.Root
+	if a, ok := root.(sham.Array); ok {
+		prefix, root = "$[*]", a.Inner
+	}
+
+	o, ok := root.(sham.Object)
+	if !ok {
+		return nil
+	}
+
+	fields := make([]benchField, len(o.Values))
+	for i, kv := range o.Values {
+		fields[i] = benchField{path: prefix + "." + kv.Key, schema: sham.Schema{Root: kv.Value}}
+	}
+	return fields
+}
+
+// benchResult is the outcome of a single measurement.
+type benchResult struct {
+	docs    int
+	bytes   int
+	elapsed time.Duration
+	allocs  uint64
+	alloced uint64
+}
+
+// measure runs the benchmark repeatedly for the duration.
+func measure(d time.Duration, run func(r *rand.Rand) (int, error)) (benchResult, error) {
+	r := rand.New(rand.NewSource(1))
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	var res benchResult
+	start := time.Now()
+	for res.elapsed < d {
+		// Checking the time after every document would dominate the cost of
+		// small schemas, so documents are generated in batches.
+		for i := 0; i < 100; i++ {
+			n, err := run(r)
+			if err != nil {
+				return benchResult{}, err
+			}
+			res.bytes += n
+			res.docs++
+		}
+		res.elapsed = time.Since(start)
+	}
+
+	runtime.ReadMemStats(&after)
+	res.allocs = after.Mallocs - before.Mallocs
+	res.alloced = after.TotalAlloc - before.TotalAlloc
+	return res, nil
+}
+
+func (r benchResult) write(tw *tabwriter.Writer, name string) {
+	secs := r.elapsed.Seconds()
+	docs := float64(r.docs)
+
+	mbs := "-"
+	if r.bytes > 0 {
+		mbs = fmt.Sprintf("%.2f", float64(r.bytes)/secs/1e6)
+	}
+
+	fmt.Fprintf(tw, "%s\t%.0f\t%s\t%.1f\t%.0f\t\n", name, docs/secs, mbs, float64(r.allocs)/docs, float64(r.alloced)/docs)
+}
+
+// countingWriter discards its input while counting the bytes written.
+type countingWriter struct {
+	n int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += len(p)
+	return ioutil.Discard.Write(p)
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/mattmeyers/sham/gogen"
+)
+
+// runGenGo implements the gen-go subcommand. The schema is read in the same
+// way as the main command, and the generated Go source is written to stdout.
+func runGenGo(args []string) {
+	var opts gogen.Options
+
+	fs := flag.NewFlagSet("gen-go", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Println(`gen-go generates Go types and constructors from a sham schema
+
+Usage:
+
+	sham gen-go [options] <schema>
+
+Options:
+	-pkg name	the name of the generated package (default main)
+	-type name	the name of the root type (default Data)
+	-locale code	the locale of generated names, addresses and phone numbers
+	-h, --help	show this help message`)
+	}
+	fs.StringVar(&opts.Package, "pkg", "main", "the name of the generated package")
+	fs.StringVar(&opts.Type, "type", "Data", "the name of the root type")
+	fs.StringVar(&oLocale, "locale", "", "the locale of generated names, addresses and phone numbers")
+	_ = fs.Parse(args)
+
+	s, err := loadSchema(fs.Args())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := gogen.Generate(s, opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	writeToStdout(src)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/mattmeyers/sham"
+)
+
+// runInfer implements the infer subcommand. Each argument is read as a sample
+// JSON document, and the schema inferred from all of the samples is written to
+// stdout.
+func runInfer(args []string) {
+	fs := flag.NewFlagSet("infer", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Println(`infer derives a sham schema from sample JSON documents
+
+Usage:
+
+	sham infer <file>...
+
+Options:
+	-h, --help	show this help message`)
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	samples := make([][]byte, fs.NArg())
+	for i, path := range fs.Args() {
+		d, err := ioutil.ReadFile(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		samples[i] = d
+	}
+
+	s, err := sham.Infer(samples...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	writeToStdout([]byte(s.String()))
+}
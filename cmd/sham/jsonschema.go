@@ -0,0 +1,46 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/mattmeyers/sham"
+)
+
+// runFromJSONSchema implements the from-jsonschema subcommand. The argument is
+// read as a JSON Schema document, and the equivalent sham schema is written to
+// stdout.
+func runFromJSONSchema(args []string) {
+	fs := flag.NewFlagSet("from-jsonschema", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Println(`from-jsonschema converts a JSON Schema document into a sham schema
+
+Usage:
+
+	sham from-jsonschema <file>
+
+Options:
+	-h, --help	show this help message`)
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	d, err := ioutil.ReadFile(fs.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	s, err := sham.ImportJSONSchema(d)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	writeToStdout([]byte(s.String()))
+}
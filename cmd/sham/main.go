@@ -1,8 +1,8 @@
 package main
 
 import (
-	"encoding/json"
-	"encoding/xml"
+	"bufio"
+	"context"
 	"errors"
 	"flag"
 	"fmt"
@@ -10,6 +10,8 @@ import (
 	"log"
 	"math/rand"
 	"os"
+	"os/signal"
+	"runtime"
 	"strings"
 	"time"
 
@@ -40,10 +42,40 @@ func (f *format) Get() interface{} { return string(*f) }
 
 func (f *format) String() string { return string(*f) }
 
+// wordLists is a repeatable flag registering word list files as terminal
+// generators, each given as name=path.
+type wordLists map[string]string
+
+func (w wordLists) Set(s string) error {
+	i := strings.Index(s, "=")
+	if i <= 0 || i == len(s)-1 {
+		return errors.New("expected name=path")
+	}
+
+	w[s[:i]] = s[i+1:]
+
+	return nil
+}
+
+func (w wordLists) String() string {
+	lists := make([]string, 0, len(w))
+	for name, path := range w {
+		lists = append(lists, name+"="+path)
+	}
+	return strings.Join(lists, ",")
+}
+
 var (
 	oPrettyPrint bool
 	oCount       int
+	oWorkers     int
 	oOutFormat   format = format("json")
+	oOpenAPI     string
+	oComponent   string
+	oResponse    string
+	oStatus      string
+	oLocale      string
+	oWordLists   = wordLists{}
 )
 
 func initCLIApp() {
@@ -53,47 +85,145 @@ func initCLIApp() {
 Usage:
 
 	sham [options] <schema>
+	sham <command> [arguments]
+
+Commands:
+	infer		derive a schema from sample JSON documents
+	from-jsonschema	convert a JSON Schema document into a schema
+	gen-go		generate Go types and constructors from a schema
+	bench		measure the cost of generating data from a schema
 
 Options:
 	-f value	set the output format: json, xml (default json)
 	-n int		the number of generations to perform (default 1)		
+	-j int		the number of generations to perform in parallel
+			(default the number of CPUs)
 	-pretty		pretty print the result
+	-locale code	the locale of generated names, addresses and phone
+			numbers: de_DE, en_US, fr_FR, ja_JP, pt_BR
+			(default en_US, or the schema's @locale directive)
+	-wordlist name=path
+			register the word list file as the terminal generator
+			name, holding one word per line or, in a .csv file,
+			a word and an optional weight per row (repeatable)
+	-openapi file	generate data from an OpenAPI 3 document instead of a schema
+	-component name	the component schema to generate with -openapi
+	-response op	the operation response to generate with -openapi, given
+			as an operationId or as "METHOD /path"
+	-status code	the response status code to use with -response
+			(default the first 2XX response)
 	-h, --help	show this help message`)
 	}
 
 	flag.BoolVar(&oPrettyPrint, "pretty", false, "pretty print the output")
 	flag.IntVar(&oCount, "n", 1, "the number of generations to perform")
+	flag.IntVar(&oWorkers, "j", runtime.NumCPU(), "the number of generations to perform in parallel")
 	flag.Var(&oOutFormat, "f", "set the output format: json, xml")
+	flag.StringVar(&oLocale, "locale", "", "the locale of generated names, addresses and phone numbers")
+	flag.Var(oWordLists, "wordlist", "register a word list file as a terminal generator, given as name=path")
+	flag.StringVar(&oOpenAPI, "openapi", "", "generate data from an OpenAPI 3 document")
+	flag.StringVar(&oComponent, "component", "", "the component schema to generate with -openapi")
+	flag.StringVar(&oResponse, "response", "", "the operation response to generate with -openapi")
+	flag.StringVar(&oStatus, "status", "", "the response status code to use with -response")
 	flag.Parse()
 }
 
+// commands maps subcommand names to their entrypoints. Each command receives
+// the arguments following its name.
+var commands = map[string]func(args []string){
+	"infer":           runInfer,
+	"from-jsonschema": runFromJSONSchema,
+	"gen-go":          runGenGo,
+	"bench":           runBench,
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		if cmd, ok := commands[os.Args[1]]; ok {
+			cmd(os.Args[2:])
+			stdout.Flush()
+			return
+		}
+	}
+
 	initCLIApp()
-	rand.Seed(time.Now().Unix())
+	seed := time.Now().Unix()
+	rand.Seed(seed)
+	r := rand.New(rand.NewSource(seed))
 
-	schema, err := readFromStdin()
+	var p sham.Schema
+	var err error
+	if oOpenAPI != "" {
+		p, err = loadOpenAPISchema()
+	} else {
+		p, err = loadSchema(flag.Args())
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	if n := flag.NArg(); n > 1 || (schema != nil && n > 0) {
-		log.Fatal("only a single schema can be processed")
-	} else if n == 1 {
-		schema = []byte(flag.Arg(0))
+	ctx, stop := notifyInterrupt()
+	defer stop()
+
+	enc := sham.NewEncoder(stdout, sham.Format(oOutFormat))
+	enc.Rand = r
+	if oPrettyPrint {
+		enc.Indent = "    "
 	}
 
-	p, err := sham.NewDefaultParser(schema).Parse()
+	err = enc.EncodeN(ctx, p, oCount, oWorkers)
+	if errors.Is(err, context.Canceled) {
+		os.Exit(130)
+	} else if err != nil {
+		fatal(err)
+	}
+}
+
+// notifyInterrupt returns a context that is cancelled when the process
+// receives SIGINT, allowing the generations completed so far to be written
+// before exiting. Calling stop restores the default signal behaviour.
+func notifyInterrupt() (context.Context, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt)
+	go func() {
+		select {
+		case <-sig:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, func() {
+		signal.Stop(sig)
+		cancel()
+	}
+}
+
+// loadSchema parses the schema provided either on stdin or as the single
+// positional argument, using the locale given by -locale and the word lists
+// given by -wordlist.
+func loadSchema(args []string) (sham.Schema, error) {
+	schema, err := readFromStdin()
 	if err != nil {
-		log.Fatal(err)
+		return sham.Schema{}, err
+	}
+
+	if n := len(args); n > 1 || (schema != nil && n > 0) {
+		return sham.Schema{}, errors.New("only a single schema can be processed")
+	} else if n == 1 {
+		schema = []byte(args[0])
 	}
 
-	for i := 0; i < oCount; i++ {
-		e, err := encoders[string(oOutFormat)](p.Generate())
-		if err != nil {
-			log.Fatal(err)
+	p := sham.NewDefaultParser(schema)
+	p.Locale = oLocale
+	for name, path := range oWordLists {
+		if err := p.RegisterWordList(name, path); err != nil {
+			return sham.Schema{}, err
 		}
-		writeToStdout(e)
 	}
+	return p.Parse()
 }
 
 func readFromStdin() ([]byte, error) {
@@ -109,45 +239,16 @@ func readFromStdin() ([]byte, error) {
 	return ioutil.ReadAll(os.Stdin)
 }
 
-type encoder func(interface{}) ([]byte, error)
+// stdout buffers the generated output. It must be flushed before exiting.
+var stdout = bufio.NewWriter(os.Stdout)
 
-var encoders = map[string]encoder{
-	"json": encodeJSON,
-	"xml":  encodeXML,
+func writeToStdout(d []byte) {
+	stdout.Write(d)
+	stdout.WriteByte('\n')
 }
 
-func encodeJSON(d interface{}) ([]byte, error) {
-	var out []byte
-	var err error
-
-	if oPrettyPrint {
-		out, err = json.MarshalIndent(d, "", "    ")
-	} else {
-		out, err = json.Marshal(d)
-	}
-
-	if err != nil {
-		return nil, err
-	}
-
-	return out, nil
-}
-
-func encodeXML(d interface{}) ([]byte, error) {
-	var out []byte
-	var err error
-
-	if oPrettyPrint {
-		out, err = xml.MarshalIndent(d, "", "    ")
-	} else {
-		out, err = xml.Marshal(d)
-	}
-
-	if err != nil {
-		return nil, err
-	}
-
-	return out, nil
+// fatal flushes the output written so far and exits with the error.
+func fatal(err error) {
+	stdout.Flush()
+	log.Fatal(err)
 }
-
-func writeToStdout(d []byte) { fmt.Fprintln(os.Stdout, string(d)) }
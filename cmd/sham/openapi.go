@@ -0,0 +1,39 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/mattmeyers/sham"
+)
+
+// loadOpenAPISchema reads the OpenAPI document given by the -openapi flag and
+// builds the schema selected by either the -component or -response flag.
+func loadOpenAPISchema() (sham.Schema, error) {
+	d, err := ioutil.ReadFile(oOpenAPI)
+	if err != nil {
+		return sham.Schema{}, err
+	}
+
+	spec, err := sham.ImportOpenAPI(d)
+	if err != nil {
+		return sham.Schema{}, err
+	}
+
+	switch {
+	case oComponent != "" && oResponse != "":
+		return sham.Schema{}, errors.New("only one of -component and -response can be provided")
+	case oComponent != "":
+		return spec.Component(oComponent)
+	case oResponse != "":
+		return spec.Response(oResponse, oStatus)
+	}
+
+	return sham.Schema{}, fmt.Errorf(
+		"one of -component or -response is required\n\ncomponents:\n\t%s\n\noperations:\n\t%s",
+		strings.Join(spec.Components(), "\n\t"),
+		strings.Join(spec.Operations(), "\n\t"),
+	)
+}
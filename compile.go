@@ -0,0 +1,590 @@
+package sham
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"regexp/syntax"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Program is a schema compiled into a flat list of instructions. Compiling
+// resolves the work that the AST repeats on every generation: regular
+// expressions are flattened into instruction lists that write directly to a
+// byte buffer, formatted strings are split into their literal segments,
+// object keys and literals are encoded ahead of time, and objects know their
+// size before they are built.
+//
+// A program draws the same random decisions as the schema it was compiled
+// from, so both produce identical data from identically seeded sources. Nodes
+// the program cannot run itself, such as terminal generators that may fail,
+// are left to the schema's own generation. A Program is safe for concurrent
+// use.
+type Program struct {
+	insts []inst
+	regex []reInst
+	root  int
+}
+
+type opcode uint8
+
+const (
+	opLiteral opcode = iota
+	opRange
+	opObject
+	opArray
+	opEmptyArray
+	opChoice
+	opRegex
+	opFormat
+	opTerminal
+	opNode
+)
+
+// inst is a single instruction of a Program. Children are referenced by their
+// index in the program's instruction list.
+type inst struct {
+	op opcode
+
+	// min and max bound an opRange value or the length of an opArray. A
+	// negative max denotes an array without a range, which has one element.
+	min, max int
+	// args holds the children of an opObject, opArray, opChoice or opFormat.
+	args []int
+	// keys holds the keys of an opObject, and the encoded keys used when
+	// writing JSON.
+	keys     []string
+	jsonKeys [][]byte
+	optional []bool
+	// value and json hold an opLiteral and its encoding.
+	value interface{}
+	json  []byte
+	// segments holds the literal parts of an opFormat, surrounding its
+	// parameters.
+	segments []string
+	// regex is the index of the root instruction of an opRegex.
+	regex int
+	gen   Generator
+	node  Node
+}
+
+// reInst is a single instruction of a compiled regular expression.
+type reInst struct {
+	op   syntax.Op
+	lit  string
+	args []int
+	// class and size hold the inclusive ranges of an OpCharClass and the
+	// number of runes they contain.
+	class []rune
+	size  int32
+}
+
+// Compile compiles the schema into a Program.
+func (s Schema) Compile() *Program {
+	p := &Program{}
+	if s.Root == nil {
+		p.root = p.emit(inst{op: opLiteral, json: []byte("null")})
+	} else {
+		p.root = p.compile(s.Root)
+	}
+	return p
+}
+
+func (p *Program) emit(i inst) int {
+	p.insts = append(p.insts, i)
+	return len(p.insts) - 1
+}
+
+func (p *Program) compile(n Node) int {
+	switch n := n.(type) {
+	case Object:
+		if hasDuplicateKeys(n) {
+			return p.emit(inst{op: opNode, node: n})
+		}
+
+		i := inst{op: opObject}
+		for _, kv := range n.Values {
+			k, _ := json.Marshal(kv.Key)
+			i.keys = append(i.keys, kv.Key)
+			i.jsonKeys = append(i.jsonKeys, append(k, ':'))
+			i.optional = append(i.optional, kv.Optional)
+			i.args = append(i.args, p.compile(kv.Value))
+		}
+		return p.emit(i)
+	case Array:
+		if n.Inner == nil {
+			return p.emit(inst{op: opEmptyArray})
+		}
+
+		i := inst{op: opArray, min: 1, max: -1}
+		if n.Range != nil {
+			i.min, i.max = n.Range.Min, n.Range.Max
+		}
+		i.args = []int{p.compile(n.Inner)}
+		return p.emit(i)
+	case Choice:
+		if len(n.Options) == 0 {
+			return p.emit(inst{op: opLiteral, json: []byte("null")})
+		}
+
+		i := inst{op: opChoice}
+		for _, o := range n.Options {
+			i.args = append(i.args, p.compile(o))
+		}
+		return p.emit(i)
+	case Range:
+		return p.emit(inst{op: opRange, min: n.Min, max: n.Max})
+	case Literal:
+		d, err := json.Marshal(n.Value)
+		if err != nil {
+			return p.emit(inst{op: opNode, node: n})
+		}
+		return p.emit(inst{op: opLiteral, value: n.Value, json: d})
+	case Regex:
+		if n.regex == nil {
+			return p.emit(inst{op: opNode, node: n})
+		}
+		return p.emit(inst{op: opRegex, regex: p.compileRegex(n.regex)})
+	case FormattedString:
+		// The format only holds the verbs inserted for the parameters unless
+		// the raw string contained its own, in which case fmt.Sprintf is
+		// needed to reproduce its output.
+		if len(n.Params) == 0 || strings.Count(n.Format, "%") != len(n.Params) {
+			return p.emit(inst{op: opNode, node: n})
+		}
+
+		for _, g := range n.Params {
+			if !infallible(g) {
+				return p.emit(inst{op: opNode, node: n})
+			}
+		}
+
+		i := inst{op: opFormat, segments: strings.Split(n.Format, "%v")}
+		for _, g := range n.Params {
+			i.args = append(i.args, p.emit(inst{op: opTerminal, gen: g}))
+		}
+		return p.emit(i)
+	case TerminalGenerator:
+		if !infallible(n.fn) {
+			return p.emit(inst{op: opNode, node: n})
+		}
+		return p.emit(inst{op: opTerminal, gen: n.fn})
+	}
+
+	return p.emit(inst{op: opNode, node: n})
+}
+
+// hasDuplicateKeys reports whether a key appears more than once in the object.
+// Only the last value of a duplicated key is kept, in the position of the first,
+// so these objects must be generated before they can be encoded.
+func hasDuplicateKeys(o Object) bool {
+	seen := make(map[string]bool, len(o.Values))
+	for _, kv := range o.Values {
+		if seen[kv.Key] {
+			return true
+		}
+		seen[kv.Key] = true
+	}
+	return false
+}
+
+// infallible reports whether a generator can be run without checking for
+// failures, which is left to the schema's generation.
+func infallible(g Generator) bool {
+	if g == nil {
+		return false
+	}
+	_, ok := g.(ErrGenerator)
+	return !ok
+}
+
+func (p *Program) compileRegex(re *syntax.Regexp) int {
+	i := reInst{op: re.Op}
+	switch re.Op {
+	case syntax.OpLiteral:
+		i.lit = string(re.Rune)
+	case syntax.OpCharClass:
+		i.class = re.Rune
+		for j := 0; j+1 < len(re.Rune); j += 2 {
+			i.size += re.Rune[j+1] - re.Rune[j] + 1
+		}
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpCapture:
+		i.args = []int{p.compileRegex(re.Sub0[0])}
+	case syntax.OpConcat, syntax.OpAlternate:
+		for _, s := range re.Sub {
+			i.args = append(i.args, p.compileRegex(s))
+		}
+	}
+
+	p.regex = append(p.regex, i)
+	return len(p.regex) - 1
+}
+
+// Generate performs a single generation using the global source.
+func (p *Program) Generate() interface{} { return p.GenerateRand(globalRand) }
+
+// GenerateRand performs a single generation using the provided source. The
+// result is identical to the result of the compiled schema's GenerateRand.
+func (p *Program) GenerateRand(r *rand.Rand) interface{} {
+	return p.generate(p.root, r)
+}
+
+func (p *Program) generate(pc int, r *rand.Rand) interface{} {
+	i := &p.insts[pc]
+	switch i.op {
+	case opLiteral:
+		return i.value
+	case opRange:
+		return randInt(r, i.min, i.max)
+	case opObject:
+		out := &OrderedMap{
+			Values: make(map[string]interface{}, len(i.keys)),
+			Keys:   make([]string, 0, len(i.keys)),
+		}
+		for j, k := range i.keys {
+			if i.optional[j] && r.Intn(2) == 0 {
+				continue
+			}
+			out.Keys = append(out.Keys, k)
+			out.Values[k] = p.generate(i.args[j], r)
+		}
+		return out
+	case opArray:
+		out := make([]interface{}, p.length(i, r))
+		for j := range out {
+			out[j] = p.generate(i.args[0], r)
+		}
+		return out
+	case opEmptyArray:
+		return []interface{}{}
+	case opChoice:
+		return p.generate(i.args[r.Intn(len(i.args))], r)
+	case opRegex:
+		return string(p.appendRegex(nil, i.regex, r))
+	case opFormat:
+		return string(p.appendFormat(nil, i, r))
+	case opTerminal:
+		return generateRand(i.gen, r)
+	}
+
+	return generateRand(i.node, r)
+}
+
+// GenerateE performs a single generation like GenerateRand, but reports
+// failures in the same way as the compiled schema's GenerateE.
+func (p *Program) GenerateE(ctx context.Context, r *rand.Rand) (interface{}, error) {
+	x := run{p: p, ctx: ctx, r: r}
+	return x.generate(p.root)
+}
+
+// run is a single generation of a Program that checks the context before
+// each instruction and tracks the path of the value being generated, so that
+// failures can be located. Paths are only formatted when an error occurs.
+type run struct {
+	p    *Program
+	ctx  context.Context
+	r    *rand.Rand
+	path []pathElem
+}
+
+// pathElem is a single step in the path to a value, either an object key or,
+// when index is not negative, an array index.
+type pathElem struct {
+	key   string
+	index int
+}
+
+func (x *run) generate(pc int) (interface{}, error) {
+	if err := x.ctx.Err(); err != nil {
+		return nil, x.errorf(err)
+	}
+
+	i := &x.p.insts[pc]
+	switch i.op {
+	case opObject:
+		out := &OrderedMap{
+			Values: make(map[string]interface{}, len(i.keys)),
+			Keys:   make([]string, 0, len(i.keys)),
+		}
+		for j, k := range i.keys {
+			if i.optional[j] && x.r.Intn(2) == 0 {
+				continue
+			}
+
+			x.path = append(x.path, pathElem{key: k, index: -1})
+			v, err := x.generate(i.args[j])
+			if err != nil {
+				return nil, err
+			}
+			x.path = x.path[:len(x.path)-1]
+
+			out.Keys = append(out.Keys, k)
+			out.Values[k] = v
+		}
+		return out, nil
+	case opArray:
+		out := make([]interface{}, x.p.length(i, x.r))
+		for j := range out {
+			x.path = append(x.path, pathElem{index: j})
+			v, err := x.generate(i.args[0])
+			if err != nil {
+				return nil, err
+			}
+			x.path = x.path[:len(x.path)-1]
+
+			out[j] = v
+		}
+		return out, nil
+	case opChoice:
+		return x.generate(i.args[x.r.Intn(len(i.args))])
+	case opNode:
+		v, err := generateNode(x.ctx, i.node, x.r, "$")
+		if ge, ok := err.(*GenerateError); ok {
+			ge.Path = x.pathString() + strings.TrimPrefix(ge.Path, "$")
+		}
+		return v, err
+	}
+
+	return x.p.generate(pc, x.r), nil
+}
+
+func (x *run) errorf(err error) error {
+	return &GenerateError{Path: x.pathString(), Err: err}
+}
+
+func (x *run) pathString() string {
+	var sb strings.Builder
+	sb.WriteByte('$')
+	for _, p := range x.path {
+		if p.index < 0 {
+			sb.WriteByte('.')
+			sb.WriteString(p.key)
+		} else {
+			sb.WriteByte('[')
+			sb.WriteString(strconv.Itoa(p.index))
+			sb.WriteByte(']')
+		}
+	}
+	return sb.String()
+}
+
+// AppendJSON performs a single generation using the provided source and
+// appends its JSON encoding to dst. The encoding is identical to the result
+// of json.Marshal on the compiled schema's GenerateRand result. Values are
+// written as they are generated, so no intermediate objects or arrays are
+// built.
+func (p *Program) AppendJSON(dst []byte, r *rand.Rand) ([]byte, error) {
+	return p.appendJSON(dst, p.root, r)
+}
+
+func (p *Program) appendJSON(dst []byte, pc int, r *rand.Rand) ([]byte, error) {
+	var err error
+
+	i := &p.insts[pc]
+	switch i.op {
+	case opLiteral:
+		return append(dst, i.json...), nil
+	case opRange:
+		return strconv.AppendInt(dst, int64(randInt(r, i.min, i.max)), 10), nil
+	case opObject:
+		dst = append(dst, '{')
+		first := true
+		for j := range i.keys {
+			if i.optional[j] && r.Intn(2) == 0 {
+				continue
+			}
+
+			if !first {
+				dst = append(dst, ',')
+			}
+			first = false
+
+			dst = append(dst, i.jsonKeys[j]...)
+			if dst, err = p.appendJSON(dst, i.args[j], r); err != nil {
+				return nil, err
+			}
+		}
+		return append(dst, '}'), nil
+	case opArray:
+		dst = append(dst, '[')
+		for j, n := 0, p.length(i, r); j < n; j++ {
+			if j > 0 {
+				dst = append(dst, ',')
+			}
+			if dst, err = p.appendJSON(dst, i.args[0], r); err != nil {
+				return nil, err
+			}
+		}
+		return append(dst, ']'), nil
+	case opEmptyArray:
+		return append(dst, "[]"...), nil
+	case opChoice:
+		return p.appendJSON(dst, i.args[r.Intn(len(i.args))], r)
+	case opRegex, opFormat:
+		// The string is generated in place and only re-encoded if it contains
+		// characters that must be escaped.
+		dst = append(dst, '"')
+		start := len(dst)
+		if i.op == opRegex {
+			dst = p.appendRegex(dst, i.regex, r)
+		} else {
+			dst = p.appendFormat(dst, i, r)
+		}
+
+		if s := dst[start:]; !jsonSafe(s) {
+			return appendJSONValue(dst[:start-1], string(s))
+		}
+		return append(dst, '"'), nil
+	case opTerminal:
+		return appendJSONValue(dst, generateRand(i.gen, r))
+	}
+
+	return appendJSONValue(dst, generateRand(i.node, r))
+}
+
+// length chooses the number of elements of an opArray.
+func (p *Program) length(i *inst, r *rand.Rand) int {
+	if i.max < 0 {
+		return 1
+	}
+	return randInt(r, i.min, i.max)
+}
+
+func (p *Program) appendRegex(dst []byte, pc int, r *rand.Rand) []byte {
+	i := &p.regex[pc]
+	switch i.op {
+	case syntax.OpLiteral:
+		dst = append(dst, i.lit...)
+	case syntax.OpStar:
+		for n := r.Intn(maxRepeats); n > 0; n-- {
+			dst = p.appendRegex(dst, i.args[0], r)
+		}
+	case syntax.OpPlus:
+		for n := r.Intn(maxRepeats-1) + 1; n > 0; n-- {
+			dst = p.appendRegex(dst, i.args[0], r)
+		}
+	case syntax.OpConcat:
+		for _, a := range i.args {
+			dst = p.appendRegex(dst, a, r)
+		}
+	case syntax.OpAlternate:
+		dst = p.appendRegex(dst, i.args[r.Intn(len(i.args))], r)
+	case syntax.OpCapture:
+		dst = p.appendRegex(dst, i.args[0], r)
+	case syntax.OpCharClass:
+		dst = appendRune(dst, i.pick(r))
+	case syntax.OpQuest:
+		if r.Float64() >= 0.25 {
+			dst = p.appendRegex(dst, i.args[0], r)
+		}
+	}
+	return dst
+}
+
+// pick chooses a rune from an OpCharClass in the same way as fromCharClass.
+func (i *reInst) pick(r *rand.Rand) rune {
+	if len(i.class) < 2 {
+		return 0
+	}
+
+	n := r.Int31n(i.size)
+	for j := 0; j+1 < len(i.class); j += 2 {
+		width := i.class[j+1] - i.class[j] + 1
+		if n < width {
+			return i.class[j] + n
+		}
+		n -= width
+	}
+	return i.class[0]
+}
+
+func (p *Program) appendFormat(dst []byte, i *inst, r *rand.Rand) []byte {
+	dst = append(dst, i.segments[0]...)
+	for j, a := range i.args {
+		switch v := generateRand(p.insts[a].gen, r).(type) {
+		case string:
+			dst = append(dst, v...)
+		case int:
+			dst = strconv.AppendInt(dst, int64(v), 10)
+		default:
+			dst = append(dst, fmt.Sprint(v)...)
+		}
+		dst = append(dst, i.segments[j+1]...)
+	}
+	return dst
+}
+
+// randInt chooses an integer from the inclusive range [min, max] in the same
+// way as Range.GetValueRand.
+func randInt(r *rand.Rand, min, max int) int {
+	if min == max {
+		return min
+	}
+	return r.Intn((max+1)-min) + min
+}
+
+// appendRune appends the UTF-8 encoding of a rune, converting invalid runes
+// to utf8.RuneError like a conversion from []rune to string.
+func appendRune(dst []byte, c rune) []byte {
+	if c < utf8.RuneSelf && c >= 0 {
+		return append(dst, byte(c))
+	}
+
+	var b [utf8.UTFMax]byte
+	n := utf8.EncodeRune(b[:], c)
+	return append(dst, b[:n]...)
+}
+
+// appendJSONValue appends the JSON encoding of a generated value, handling
+// common types without reflection.
+func appendJSONValue(dst []byte, v interface{}) ([]byte, error) {
+	switch v := v.(type) {
+	case nil:
+		return append(dst, "null"...), nil
+	case string:
+		if jsonSafeString(v) {
+			dst = append(dst, '"')
+			dst = append(dst, v...)
+			return append(dst, '"'), nil
+		}
+	case int:
+		return strconv.AppendInt(dst, int64(v), 10), nil
+	case bool:
+		return strconv.AppendBool(dst, v), nil
+	}
+
+	d, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append(dst, d...), nil
+}
+
+// jsonSafe reports whether the bytes can be placed between quotes as a JSON
+// string without escaping. The characters escaped by encoding/json to keep
+// JSON safe for embedding in HTML are not considered safe.
+func jsonSafe(s []byte) bool {
+	for _, c := range s {
+		if !jsonSafeByte(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// jsonSafeString is like jsonSafe for strings.
+func jsonSafeString(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !jsonSafeByte(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func jsonSafeByte(c byte) bool {
+	return c >= 0x20 && c < utf8.RuneSelf && c != '"' && c != '\\' && c != '<' && c != '>' && c != '&'
+}
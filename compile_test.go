@@ -0,0 +1,190 @@
+package sham
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+var compileSchemas = []string{
+	`{"name": name, "age": (18,99), "friends": [(0,3), {"name": name, "phone"?: phoneNumber}], "bio": null}`,
+	`[(0,4), [(0,2), "a" | 1 | true | 2.5]]`,
+	`{"created": timestamp, "tags": [], "meta": {}, "code": /[A-Z]{2}<&>\d+(x|yz)?/, "unicode": /[α-ω]{3}/}`,
+	"{\"greeting\": `hello {firstName} {lastName}!`, \"percent\": `100% {name}`}",
+	`{"a": 1, "b": [(1,3), (0,9)], "a": 2}`,
+	`[(5,5), boolean]`,
+	`"plain"`,
+	`{"C:\path": 1, "<&>": {"a\b": true}}`,
+}
+
+func TestProgram(t *testing.T) {
+	for _, schema := range compileSchemas {
+		s, err := NewDefaultParser([]byte(schema)).Parse()
+		if err != nil {
+			t.Fatalf("Parse(%s) error = %v", schema, err)
+		}
+		p := s.Compile()
+
+		for seed := int64(0); seed < 20; seed++ {
+			want := s.GenerateRand(rand.New(rand.NewSource(seed)))
+			if got := p.GenerateRand(rand.New(rand.NewSource(seed))); !reflect.DeepEqual(got, want) {
+				t.Errorf("GenerateRand(%s) = %v, want %v", schema, got, want)
+			}
+
+			wantJSON, err := json.Marshal(want)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			gotJSON, err := p.AppendJSON(nil, rand.New(rand.NewSource(seed)))
+			if err != nil {
+				t.Fatalf("AppendJSON(%s) error = %v", schema, err)
+			}
+			if string(gotJSON) != string(wantJSON) {
+				t.Errorf("AppendJSON(%s) = %s, want %s", schema, gotJSON, wantJSON)
+			}
+
+			got, err := p.GenerateE(context.Background(), rand.New(rand.NewSource(seed)))
+			if err != nil {
+				t.Fatalf("GenerateE(%s) error = %v", schema, err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("GenerateE(%s) = %v, want %v", schema, got, want)
+			}
+		}
+	}
+}
+
+func TestProgram_GenerateE_Error(t *testing.T) {
+	errLookup := errors.New("lookup failed")
+	fail := ErrGeneratorFunc(func(ctx context.Context, r *rand.Rand) (interface{}, error) { return nil, errLookup })
+
+	tests := []struct {
+		name   string
+		schema string
+		ctx    func() context.Context
+		want   error
+		path   string
+	}{
+		{
+			name:   "Terminal generator",
+			schema: `{"friends": [(2,2), {"id": (1,9), "phone": fail}]}`,
+			want:   errLookup,
+			path:   "$.friends[0].phone",
+		},
+		{
+			name:   "Formatted string",
+			schema: "{\"a\": [(1,1), `call {fail}`]}",
+			want:   errLookup,
+			path:   "$.a[0]",
+		},
+		{
+			name:   "Nil terminal generator",
+			schema: `{"a": missing}`,
+			path:   "$.a",
+		},
+		{
+			name:   "Cancelled",
+			schema: `{"a": [(1,1), (1,9)]}`,
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			},
+			want: context.Canceled,
+			path: "$",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewParser([]byte(tt.schema))
+			p.TerminalGenerators = map[string]Generator{"fail": fail, "missing": nil}
+			s, err := p.Parse()
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			ctx := context.Background()
+			if tt.ctx != nil {
+				ctx = tt.ctx()
+			}
+
+			_, err = s.Compile().GenerateE(ctx, rand.New(rand.NewSource(1)))
+			var ge *GenerateError
+			if !errors.As(err, &ge) || (tt.want != nil && !errors.Is(err, tt.want)) {
+				t.Fatalf("GenerateE() error = %v, want %v", err, tt.want)
+			}
+			if ge.Path != tt.path {
+				t.Errorf("GenerateE() path = %s, want %s", ge.Path, tt.path)
+			}
+		})
+	}
+}
+
+const benchmarkSchema = `[(10,10), {
+	"id": /[a-f0-9]{8}-[a-f0-9]{4}/,
+	"name": name,
+	"age": (18,99),
+	"email": ` + "`{firstName}@example.com`" + `,
+	"active": boolean,
+	"tags": [(0,5), "a" | "b" | "c"]
+}]`
+
+func BenchmarkSchema_GenerateRand(b *testing.B) {
+	s := benchmarkParse(b)
+	r := rand.New(rand.NewSource(1))
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.GenerateRand(r)
+	}
+}
+
+func BenchmarkProgram_GenerateRand(b *testing.B) {
+	p := benchmarkParse(b).Compile()
+	r := rand.New(rand.NewSource(1))
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		p.GenerateRand(r)
+	}
+}
+
+func BenchmarkSchema_GenerateRand_JSON(b *testing.B) {
+	s := benchmarkParse(b)
+	r := rand.New(rand.NewSource(1))
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		d, err := json.Marshal(s.GenerateRand(r))
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.SetBytes(int64(len(d)))
+	}
+}
+
+func BenchmarkProgram_AppendJSON(b *testing.B) {
+	p := benchmarkParse(b).Compile()
+	r := rand.New(rand.NewSource(1))
+
+	var buf []byte
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var err error
+		if buf, err = p.AppendJSON(buf[:0], r); err != nil {
+			b.Fatal(err)
+		}
+		b.SetBytes(int64(len(buf)))
+	}
+}
+
+func benchmarkParse(b *testing.B) Schema {
+	s, err := NewDefaultParser([]byte(benchmarkSchema)).Parse()
+	if err != nil {
+		b.Fatal(err)
+	}
+	return s
+}
@@ -0,0 +1,289 @@
+package sham
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// Format is an output format supported by Encoder.
+type Format string
+
+// The formats supported by Encoder. The output of each format matches the
+// corresponding encoding/json or encoding/xml encoding of the value returned
+// by Schema.Generate.
+const (
+	FormatJSON Format = "json"
+	FormatXML  Format = "xml"
+)
+
+// Encoder writes generated data directly to an output stream. Rather than
+// building the whole value before encoding it, each node is encoded as soon as
+// it is generated, so the memory used does not grow with the size of the
+// generated arrays. Only the values of terminal generators, and objects with
+// duplicated keys, are held in memory while they are encoded. Schemas are
+// compiled into a Program before they are encoded.
+type Encoder struct {
+	w      *bufio.Writer
+	format Format
+
+	// Indent pretty prints the output when non-empty, using the string as a
+	// single level of indentation.
+	Indent string
+	// Rand is the source of every random decision. If nil, the global source is
+	// used.
+	Rand *rand.Rand
+
+	run
+	xml     *xml.Encoder
+	scratch []byte
+}
+
+// NewEncoder returns an encoder writing to w in the given format.
+func NewEncoder(w io.Writer, f Format) *Encoder {
+	bw, ok := w.(*bufio.Writer)
+	if !ok {
+		bw = bufio.NewWriter(w)
+	}
+	return &Encoder{w: bw, format: f}
+}
+
+// Encode performs a single generation of the schema, writes it to the stream
+// followed by a newline, and flushes the output. Generation stops once the
+// context is done. Failures are returned as a *GenerateError, in which case
+// the output holds an incomplete value.
+//
+// The schema is compiled on every call. When encoding a schema repeatedly,
+// compile it once and use EncodeProgram.
+func (e *Encoder) Encode(ctx context.Context, s Schema) error {
+	return e.EncodeProgram(ctx, s.Compile())
+}
+
+// EncodeProgram is like Encode for a compiled schema.
+func (e *Encoder) EncodeProgram(ctx context.Context, p *Program) error {
+	e.run = run{p: p, ctx: ctx, r: e.Rand, path: e.path[:0]}
+	if e.r == nil {
+		e.r = globalRand
+	}
+
+	var err error
+	switch e.format {
+	case FormatJSON:
+		err = e.encodeJSON(p.root, 0)
+	case FormatXML:
+		e.xml = xml.NewEncoder(e.w)
+		e.xml.Indent("", e.Indent)
+		err = e.encodeXML(p.root, nil)
+		if err == nil {
+			err = e.xml.Flush()
+		}
+	default:
+		err = fmt.Errorf("sham: unknown format %q", e.format)
+	}
+
+	if err == nil {
+		err = e.w.WriteByte('\n')
+	}
+	if ferr := e.w.Flush(); err == nil {
+		err = ferr
+	}
+	return err
+}
+
+// Encode performs a single generation and writes it to w in the given format.
+// See Encoder for details.
+func (s Schema) Encode(w io.Writer, f Format) error {
+	return NewEncoder(w, f).Encode(context.Background(), s)
+}
+
+func (e *Encoder) encodeJSON(pc, depth int) error {
+	if err := e.ctx.Err(); err != nil {
+		return e.errorf(err)
+	}
+
+	i := &e.p.insts[pc]
+	switch i.op {
+	case opObject:
+		e.w.WriteByte('{')
+		written := 0
+		for j, k := range i.keys {
+			if i.optional[j] && e.r.Intn(2) == 0 {
+				continue
+			}
+
+			if written > 0 {
+				e.w.WriteByte(',')
+			}
+			e.newline(depth + 1)
+			e.w.Write(i.jsonKeys[j])
+			if e.Indent != "" {
+				e.w.WriteByte(' ')
+			}
+			written++
+
+			e.path = append(e.path, pathElem{key: k, index: -1})
+			if err := e.encodeJSON(i.args[j], depth+1); err != nil {
+				return err
+			}
+			e.path = e.path[:len(e.path)-1]
+		}
+		if written > 0 {
+			e.newline(depth)
+		}
+		return e.w.WriteByte('}')
+	case opArray, opEmptyArray:
+		size := 0
+		if i.op == opArray {
+			size = e.p.length(i, e.r)
+		}
+
+		e.w.WriteByte('[')
+		for j := 0; j < size; j++ {
+			if j > 0 {
+				e.w.WriteByte(',')
+			}
+			e.newline(depth + 1)
+
+			e.path = append(e.path, pathElem{index: j})
+			if err := e.encodeJSON(i.args[0], depth+1); err != nil {
+				return err
+			}
+			e.path = e.path[:len(e.path)-1]
+		}
+		if size > 0 {
+			e.newline(depth)
+		}
+		return e.w.WriteByte(']')
+	case opChoice:
+		return e.encodeJSON(i.args[e.r.Intn(len(i.args))], depth)
+	case opLiteral:
+		if e.Indent == "" {
+			_, err := e.w.Write(i.json)
+			return err
+		}
+	case opRange, opRegex, opFormat:
+		// These values are never indented, so they are written exactly as
+		// the program encodes them.
+		e.scratch, _ = e.p.appendJSON(e.scratch[:0], pc, e.r)
+		_, err := e.w.Write(e.scratch)
+		return err
+	}
+
+	return e.encodeJSONLeaf(pc, depth)
+}
+
+// encodeJSONLeaf generates the value of an instruction and encodes it in one
+// piece.
+func (e *Encoder) encodeJSONLeaf(pc, depth int) error {
+	v, err := e.generate(pc)
+	if err != nil {
+		return err
+	}
+
+	switch v := v.(type) {
+	case nil:
+		_, err = e.w.WriteString("null")
+	case int:
+		e.scratch = strconv.AppendInt(e.scratch[:0], int64(v), 10)
+		_, err = e.w.Write(e.scratch)
+	case bool:
+		_, err = e.w.WriteString(strconv.FormatBool(v))
+	default:
+		var d []byte
+		if e.Indent != "" {
+			d, err = json.MarshalIndent(v, strings.Repeat(e.Indent, depth), e.Indent)
+		} else {
+			d, err = json.Marshal(v)
+		}
+		if err != nil {
+			return e.errorf(err)
+		}
+		_, err = e.w.Write(d)
+	}
+	return err
+}
+
+func (e *Encoder) newline(depth int) {
+	if e.Indent == "" {
+		return
+	}
+
+	e.w.WriteByte('\n')
+	for i := 0; i < depth; i++ {
+		e.w.WriteString(e.Indent)
+	}
+}
+
+// encodeXML encodes an instruction within the start element. A nil start
+// element follows the naming rules of encoding/xml for values without a name:
+// objects are written without an enclosing element, and other values are named
+// after their type. Arrays repeat the start element for each of their
+// elements.
+func (e *Encoder) encodeXML(pc int, start *xml.StartElement) error {
+	if err := e.ctx.Err(); err != nil {
+		return e.errorf(err)
+	}
+
+	i := &e.p.insts[pc]
+	switch i.op {
+	case opObject:
+		if start != nil {
+			if err := e.xml.EncodeToken(*start); err != nil {
+				return err
+			}
+		}
+		for j, k := range i.keys {
+			if i.optional[j] && e.r.Intn(2) == 0 {
+				continue
+			}
+
+			e.path = append(e.path, pathElem{key: k, index: -1})
+			if err := e.encodeXML(i.args[j], &xml.StartElement{Name: xml.Name{Local: k}}); err != nil {
+				return err
+			}
+			e.path = e.path[:len(e.path)-1]
+		}
+		if start != nil {
+			return e.xml.EncodeToken(start.End())
+		}
+		return nil
+	case opArray:
+		for j, n := 0, e.p.length(i, e.r); j < n; j++ {
+			e.path = append(e.path, pathElem{index: j})
+			if err := e.encodeXML(i.args[0], start); err != nil {
+				return err
+			}
+			e.path = e.path[:len(e.path)-1]
+		}
+		return nil
+	case opEmptyArray:
+		return nil
+	case opChoice:
+		return e.encodeXML(i.args[e.r.Intn(len(i.args))], start)
+	}
+
+	return e.encodeXMLLeaf(pc, start)
+}
+
+func (e *Encoder) encodeXMLLeaf(pc int, start *xml.StartElement) error {
+	v, err := e.generate(pc)
+	if err != nil {
+		return err
+	}
+
+	if start == nil {
+		err = e.xml.Encode(v)
+	} else {
+		err = e.xml.EncodeElement(v, *start)
+	}
+	if err != nil {
+		return e.errorf(err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package sham
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestEncoder_Encode(t *testing.T) {
+	schemas := []string{
+		`{"name": name, "age": (18,99), "friends": [(0,3), {"name": name, "phone"?: phoneNumber}], "bio": null}`,
+		`[(0,4), [(0,2), "a" | 1 | true]]`,
+		`{"created": timestamp, "tags": [], "meta": {}, "code": /[A-Z]{2}<&>/, "ratio": 1.5}`,
+		`{"a": 1, "b": [(1,3), (0,9)], "a": 2}`,
+		`"plain"`,
+		"{\"greeting\": `hello {firstName}`, \"code\": /[α-ω]{2}\"/, \"nothing\": null}",
+		`null`,
+	}
+
+	for _, schema := range schemas {
+		s, err := NewDefaultParser([]byte(schema)).Parse()
+		if err != nil {
+			t.Fatalf("Parse(%s) error = %v", schema, err)
+		}
+
+		for _, indent := range []string{"", "    "} {
+			for _, f := range []Format{FormatJSON, FormatXML} {
+				v := s.GenerateRand(rand.New(rand.NewSource(1)))
+				var want []byte
+				switch {
+				case f == FormatJSON && indent == "":
+					want, err = json.Marshal(v)
+				case f == FormatJSON:
+					want, err = json.MarshalIndent(v, "", indent)
+				case indent == "":
+					want, err = xml.Marshal(v)
+				default:
+					want, err = xml.MarshalIndent(v, "", indent)
+				}
+				if err != nil {
+					t.Fatalf("Marshal() error = %v", err)
+				}
+				want = append(want, '\n')
+
+				var buf bytes.Buffer
+				e := NewEncoder(&buf, f)
+				e.Indent = indent
+				e.Rand = rand.New(rand.NewSource(1))
+				if err := e.Encode(context.Background(), s); err != nil {
+					t.Fatalf("Encode(%s, %s) error = %v", schema, f, err)
+				}
+
+				if got := buf.Bytes(); !bytes.Equal(got, want) {
+					t.Errorf("Encode(%s, %s, %q) =\n%s\nwant\n%s", schema, f, indent, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestEncoder_Encode_Error(t *testing.T) {
+	errLookup := errors.New("lookup failed")
+	p := NewParser([]byte(`{"friends": [(2,2), {"phone": fail}]}`))
+	p.TerminalGenerators = map[string]Generator{
+		"fail": ErrGeneratorFunc(func(ctx context.Context, r *rand.Rand) (interface{}, error) { return nil, errLookup }),
+	}
+	s, err := p.Parse()
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	for _, f := range []Format{FormatJSON, FormatXML} {
+		err := NewEncoder(&bytes.Buffer{}, f).Encode(context.Background(), s)
+
+		var ge *GenerateError
+		if !errors.As(err, &ge) || !errors.Is(err, errLookup) {
+			t.Fatalf("Encode(%s) error = %v, want %v", f, err, errLookup)
+		}
+		if ge.Path != "$.friends[0].phone" {
+			t.Errorf("Encode(%s) path = %s", f, ge.Path)
+		}
+	}
+}
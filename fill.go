@@ -0,0 +1,350 @@
+package sham
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FillError describes a generated value that could not be stored in the
+// destination passed to Schema.Fill. The path identifies the position of the
+// value within the generated data, such as $.friends[3].age.
+type FillError struct {
+	Path  string
+	Value interface{}
+	Type  reflect.Type
+}
+
+func (e *FillError) Error() string {
+	return fmt.Sprintf("sham: cannot fill %v at %s with %T value %v", e.Type, e.Path, e.Value, e.Value)
+}
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Fill performs a single generation and stores the result in the value
+// pointed to by v. Objects are stored in structs, using the field's json tag
+// as its key in the same way as encoding/json, or in maps with string keys.
+// Arrays are stored in slices or arrays, and scalars are converted to the
+// destination's type when the conversion does not lose information. Timestamps
+// can be stored in a time.Time or, formatted as RFC 3339, in a string. Strings
+// are also stored in types implementing encoding.TextUnmarshaler.
+//
+// Keys without a corresponding struct field are ignored. If a value cannot be
+// stored in its destination, a *FillError describing the mismatch is returned.
+func (s Schema) Fill(v interface{}) error {
+	return s.FillRand(globalRand, v)
+}
+
+// FillRand is like Fill, but draws every random decision from the provided
+// source.
+func (s Schema) FillRand(r *rand.Rand, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("sham: Fill requires a non-nil pointer")
+	}
+
+	return fill(rv.Elem(), s.GenerateRand(r), "$")
+}
+
+func fill(dst reflect.Value, v interface{}, path string) error {
+	if v == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	if dst.Kind() != reflect.Ptr && dst.CanAddr() && dst.Addr().Type().Implements(textUnmarshalerType) {
+		if s, ok := v.(string); ok {
+			if err := dst.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+				return fmt.Errorf("sham: cannot fill %v at %s: %w", dst.Type(), path, err)
+			}
+			return nil
+		}
+	}
+
+	mismatch := &FillError{Path: path, Value: v, Type: dst.Type()}
+
+	switch dst.Kind() {
+	case reflect.Ptr:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return fill(dst.Elem(), v, path)
+	case reflect.Interface:
+		rv := reflect.ValueOf(v)
+		if !rv.Type().AssignableTo(dst.Type()) {
+			return mismatch
+		}
+		dst.Set(rv)
+		return nil
+	case reflect.Struct:
+		if dst.Type() == timeType {
+			return fillTime(dst, v, mismatch)
+		}
+
+		m, ok := v.(*OrderedMap)
+		if !ok {
+			return mismatch
+		}
+		return fillStruct(dst, m, path)
+	case reflect.Map:
+		m, ok := v.(*OrderedMap)
+		if !ok || dst.Type().Key().Kind() != reflect.String {
+			return mismatch
+		}
+		return fillMap(dst, m, path)
+	case reflect.Slice, reflect.Array:
+		arr, ok := v.([]interface{})
+		if !ok {
+			return mismatch
+		}
+		return fillList(dst, arr, path)
+	case reflect.String:
+		switch v := v.(type) {
+		case string:
+			dst.SetString(v)
+		case time.Time:
+			dst.SetString(v.Format(time.RFC3339))
+		default:
+			return mismatch
+		}
+		return nil
+	case reflect.Bool:
+		b, ok := v.(bool)
+		if !ok {
+			return mismatch
+		}
+		dst.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, ok := toInt(v)
+		if !ok || dst.OverflowInt(i) {
+			return mismatch
+		}
+		dst.SetInt(i)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		i, ok := toInt(v)
+		if !ok || i < 0 || dst.OverflowUint(uint64(i)) {
+			return mismatch
+		}
+		dst.SetUint(uint64(i))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, ok := toFloat(v)
+		if !ok || dst.OverflowFloat(f) {
+			return mismatch
+		}
+		dst.SetFloat(f)
+		return nil
+	}
+
+	return mismatch
+}
+
+func fillTime(dst reflect.Value, v interface{}, mismatch *FillError) error {
+	switch v := v.(type) {
+	case time.Time:
+		dst.Set(reflect.ValueOf(v))
+	case string:
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return mismatch
+		}
+		dst.Set(reflect.ValueOf(t))
+	default:
+		return mismatch
+	}
+	return nil
+}
+
+func fillStruct(dst reflect.Value, m *OrderedMap, path string) error {
+	fields := structFields(dst.Type())
+	for _, k := range m.Keys {
+		idx, ok := fields[k]
+		if !ok {
+			idx, ok = fields[strings.ToLower(k)]
+		}
+		if !ok {
+			continue
+		}
+
+		f, err := fieldByIndex(dst, idx)
+		if err != nil {
+			return err
+		}
+
+		if err := fill(f, m.Values[k], path+"."+k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fieldByIndex returns the nested field with the given index sequence,
+// allocating embedded struct pointers along the way.
+func fieldByIndex(v reflect.Value, idx []int) (reflect.Value, error) {
+	for i, x := range idx {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("sham: cannot set embedded pointer to unexported struct %v", v.Type().Elem())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
+
+// structFields maps the keys of a struct type to the index sequences of its
+// fields. Lowercase versions of the keys are also included to allow case
+// insensitive matches.
+func structFields(t reflect.Type) map[string][]int {
+	fields := make(map[string][]int)
+	for _, f := range jsonFields(t) {
+		fields[f.name] = f.index
+		if k := strings.ToLower(f.name); fields[k] == nil {
+			fields[k] = f.index
+		}
+	}
+	return fields
+}
+
+// jsonField is a struct field as seen by encoding/json.
+type jsonField struct {
+	name  string
+	index []int
+	field reflect.StructField
+}
+
+// jsonFields lists the fields of a struct type in declaration order, keyed by
+// their json tag and falling back to the field name. Fields tagged "-" and
+// unexported fields are skipped. Fields of embedded structs without a tag
+// are promoted unless they are shadowed by a shallower field.
+func jsonFields(t reflect.Type) []jsonField {
+	fields := make([]jsonField, 0)
+	byName := make(map[string]int)
+
+	var walk func(t reflect.Type, index []int)
+	walk = func(t reflect.Type, index []int) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag := f.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+
+			idx := append(append([]int{}, index...), i)
+			name := strings.Split(tag, ",")[0]
+
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+				walk(ft, idx)
+				continue
+			} else if f.PkgPath != "" {
+				continue
+			}
+
+			if name == "" {
+				name = f.Name
+			}
+
+			jf := jsonField{name: name, index: idx, field: f}
+			if j, ok := byName[name]; !ok {
+				byName[name] = len(fields)
+				fields = append(fields, jf)
+			} else if len(idx) < len(fields[j].index) {
+				fields[j] = jf
+			}
+		}
+	}
+	walk(t, nil)
+
+	return fields
+}
+
+func fillMap(dst reflect.Value, m *OrderedMap, path string) error {
+	if dst.IsNil() {
+		dst.Set(reflect.MakeMapWithSize(dst.Type(), len(m.Keys)))
+	}
+
+	for _, k := range m.Keys {
+		v := reflect.New(dst.Type().Elem()).Elem()
+		if err := fill(v, m.Values[k], path+"."+k); err != nil {
+			return err
+		}
+		dst.SetMapIndex(reflect.ValueOf(k).Convert(dst.Type().Key()), v)
+	}
+	return nil
+}
+
+// fillList stores a generated array in a slice or array. Like encoding/json,
+// extra elements are dropped when filling an array, and missing elements are
+// set to their zero value.
+func fillList(dst reflect.Value, arr []interface{}, path string) error {
+	n := len(arr)
+	if dst.Kind() == reflect.Slice {
+		dst.Set(reflect.MakeSlice(dst.Type(), n, n))
+	} else if n > dst.Len() {
+		n = dst.Len()
+	}
+
+	for i := 0; i < n; i++ {
+		if err := fill(dst.Index(i), arr[i], path+"["+strconv.Itoa(i)+"]"); err != nil {
+			return err
+		}
+	}
+
+	for i := n; i < dst.Len(); i++ {
+		dst.Index(i).Set(reflect.Zero(dst.Type().Elem()))
+	}
+	return nil
+}
+
+func toInt(v interface{}) (int64, bool) {
+	switch v := v.(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	case float64:
+		if v != math.Trunc(v) || v < math.MinInt64 || v >= math.MaxInt64 {
+			return 0, false
+		}
+		return int64(v), true
+	case json.Number:
+		i, err := v.Int64()
+		return i, err == nil
+	}
+	return 0, false
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,127 @@
+package sham
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type fillFriend struct {
+	Name string `json:"name"`
+	Age  uint8  `json:"age"`
+}
+
+type fillBase struct {
+	ID int64 `json:"id"`
+}
+
+type fillPerson struct {
+	fillBase
+	Name     string         `json:"name"`
+	Score    float32        `json:"score"`
+	Active   *bool          `json:"active"`
+	Created  time.Time      `json:"created"`
+	Friends  []fillFriend   `json:"friends"`
+	Tags     [2]string      `json:"tags"`
+	Meta     map[string]int `json:"meta"`
+	Extra    interface{}    `json:"extra"`
+	Ignored  string         `json:"-"`
+	Nickname string
+	Labels   map[string]string `json:"labels"`
+}
+
+func TestSchema_Fill(t *testing.T) {
+	active := true
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		schema   string
+		dst      interface{}
+		want     interface{}
+		wantPath string
+		wantErr  bool
+	}{
+		{
+			name: "Struct with nested values",
+			schema: `{
+				"id": (7),
+				"name": "Ann",
+				"score": 1.5,
+				"active": true,
+				"created": "2020-01-02T03:04:05Z",
+				"friends": [(2), {"name": "Bob", "age": (30)}],
+				"tags": [(3), "x"],
+				"meta": {"a": 1},
+				"extra": "anything",
+				"-": "skipped",
+				"nickname": "annie",
+				"labels": null,
+				"unknown": 1
+			}`,
+			dst: &fillPerson{},
+			want: &fillPerson{
+				fillBase: fillBase{ID: 7},
+				Name:     "Ann",
+				Score:    1.5,
+				Active:   &active,
+				Created:  created,
+				Friends:  []fillFriend{{Name: "Bob", Age: 30}, {Name: "Bob", Age: 30}},
+				Tags:     [2]string{"x", "x"},
+				Meta:     map[string]int{"a": 1},
+				Extra:    "anything",
+				Nickname: "annie",
+			},
+		},
+		{
+			name:   "Integral float into int",
+			schema: `2.0`,
+			dst:    new(int),
+			want:   func() *int { i := 2; return &i }(),
+		},
+		{
+			name:     "Overflow",
+			schema:   `{"friends": [{"age": (300)}]}`,
+			dst:      &fillPerson{},
+			wantPath: "$.friends[0].age",
+			wantErr:  true,
+		},
+		{
+			name:     "Type mismatch",
+			schema:   `{"name": (1)}`,
+			dst:      &fillPerson{},
+			wantPath: "$.name",
+			wantErr:  true,
+		},
+		{
+			name:    "Non-pointer destination",
+			schema:  `1`,
+			dst:     0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewDefaultParser([]byte(tt.schema)).Parse()
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			err = s.Fill(tt.dst)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Schema.Fill() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			var fe *FillError
+			if tt.wantPath != "" && (!errors.As(err, &fe) || fe.Path != tt.wantPath) {
+				t.Errorf("Schema.Fill() error = %v, want path %s", err, tt.wantPath)
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(tt.dst, tt.want) {
+				t.Errorf("Schema.Fill() = %+v, want %+v", tt.dst, tt.want)
+			}
+		})
+	}
+}
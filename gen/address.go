@@ -0,0 +1,80 @@
+package gen
+
+import (
+	"math"
+	"math/rand"
+)
+
+// PostalAddress is a complete address whose parts agree with each other. The
+// postal code belongs to the state, and the coordinates lie near the city.
+type PostalAddress struct {
+	Street      string  `json:"street"`
+	City        string  `json:"city"`
+	State       string  `json:"state"`
+	StateCode   string  `json:"stateCode"`
+	PostalCode  string  `json:"postalCode"`
+	Country     string  `json:"country"`
+	CountryCode string  `json:"countryCode"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+}
+
+func Address(r *rand.Rand) PostalAddress {
+	return DefaultLocale.Address(r)
+}
+
+func StreetAddress(r *rand.Rand) string {
+	return DefaultLocale.StreetAddress(r)
+}
+
+func City(r *rand.Rand) string {
+	return DefaultLocale.City(r)
+}
+
+func State(r *rand.Rand) string {
+	return DefaultLocale.State(r)
+}
+
+func StateCode(r *rand.Rand) string {
+	return DefaultLocale.StateCode(r)
+}
+
+func PostalCode(r *rand.Rand) string {
+	return DefaultLocale.PostalCode(r)
+}
+
+func Country(r *rand.Rand) string {
+	return countries[r.Intn(len(countries))].name
+}
+
+func CountryCode(r *rand.Rand) string {
+	return countries[r.Intn(len(countries))].code
+}
+
+func Latitude(r *rand.Rand) float64 {
+	return round(r.Float64()*180-90, 6)
+}
+
+func Longitude(r *rand.Rand) float64 {
+	return round(r.Float64()*360-180, 6)
+}
+
+// place is a city along with the state it belongs to, the start of its postal
+// codes, and its approximate coordinates.
+type place struct {
+	city      string
+	state     string
+	stateCode string
+	zip       string
+	lat, lng  float64
+}
+
+type country struct {
+	name string
+	code string
+}
+
+func round(f float64, places int) float64 {
+	p := math.Pow10(places)
+	return math.Round(f*p) / p
+}
@@ -0,0 +1,48 @@
+package gen
+
+import (
+	"math"
+	"math/rand"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestAddress(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		a := Address(r)
+
+		var p *place
+		for j := range places {
+			if places[j].city == a.City {
+				p = &places[j]
+			}
+		}
+		if p == nil {
+			t.Fatalf("Address() = %+v has an unknown city", a)
+		}
+
+		if a.State != p.state || a.StateCode != p.stateCode || !strings.HasPrefix(a.PostalCode, p.zip) || len(a.PostalCode) != 5 {
+			t.Errorf("Address() = %+v does not agree with %+v", a, *p)
+		}
+		if math.Abs(a.Latitude-p.lat) > 0.1 || math.Abs(a.Longitude-p.lng) > 0.1 {
+			t.Errorf("Address() = %+v is not near %s", a, p.city)
+		}
+		if !regexp.MustCompile(`^\d+ \w+ \w+$`).MatchString(a.Street) {
+			t.Errorf("Address() street = %q", a.Street)
+		}
+	}
+}
+
+func TestCoordinates(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		if lat := Latitude(r); lat < -90 || lat > 90 {
+			t.Fatalf("Latitude() = %v", lat)
+		}
+		if lng := Longitude(r); lng < -180 || lng > 180 {
+			t.Fatalf("Longitude() = %v", lng)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package gen
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+)
+
+func Company(r *rand.Rand) string {
+	switch r.Intn(3) {
+	case 0:
+		return LastNameRand(r) + " " + CompanySuffix(r)
+	case 1:
+		return LastNameRand(r) + "-" + LastNameRand(r)
+	default:
+		return LastNameRand(r) + ", " + LastNameRand(r) + " and " + LastNameRand(r)
+	}
+}
+
+func CompanySuffix(r *rand.Rand) string {
+	return getRandomString(r, companySuffixes)
+}
+
+func CatchPhrase(r *rand.Rand) string {
+	return getRandomString(r, catchPhraseAdjectives) + " " + getRandomString(r, catchPhraseDescriptors) + " " + getRandomString(r, catchPhraseNouns)
+}
+
+func JobTitle(r *rand.Rand) string {
+	title := getRandomString(r, jobAreas) + " " + getRandomString(r, jobRoles)
+	if r.Intn(2) == 1 {
+		title = getRandomString(r, jobLevels) + " " + title
+	}
+	return title
+}
+
+func Department(r *rand.Rand) string {
+	return getRandomString(r, departments)
+}
+
+func ProductName(r *rand.Rand) string {
+	return getRandomString(r, productAdjectives) + " " + getRandomString(r, productMaterials) + " " + getRandomString(r, productNouns)
+}
+
+// SKU generates a stock keeping unit in the form ABC-12345.
+func SKU(r *rand.Rand) string {
+	const letters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+	b := make([]byte, 3, 9)
+	for i := range b {
+		b[i] = letters[r.Intn(len(letters))]
+	}
+	return fmt.Sprintf("%s-%05d", b, r.Intn(100000))
+}
+
+// Price generates a retail price below 1000, ending in .99, .95 or .49.
+func Price(r *rand.Rand) string {
+	return strconv.Itoa(r.Intn(pow10(1+r.Intn(3)))) + getRandomString(r, priceEndings)
+}
+
+// PriceBetween generates a price between min and max inclusive, in whole
+// cents. If no whole number of cents lies between them, min rounded up to the
+// cent is returned.
+func PriceBetween(r *rand.Rand, min, max float64) string {
+	// The tolerance keeps prices such as 1.10, whose cents are not exact in
+	// floating point, within bounds.
+	lo, hi := int64(math.Ceil(min*100-1e-6)), int64(math.Floor(max*100+1e-6))
+	if hi > lo {
+		lo += r.Int63n(hi - lo + 1)
+	}
+	return fmt.Sprintf("%d.%02d", lo/100, lo%100)
+}
+
+// ean13Prefixes holds the inclusive ranges of GS1 prefixes used by EAN13. They
+// leave out the prefixes restricted to internal use (020-029, 040-049 and
+// 200-299), books and periodicals (977-979), and coupons (980-999).
+var ean13Prefixes = [][2]int{{0, 19}, {30, 39}, {50, 199}, {300, 969}}
+
+// EAN13 generates a 13 digit European Article Number. The GS1 prefix avoids
+// the ranges reserved for internal use, books and coupons.
+func EAN13(r *rand.Rand) string {
+	total := 0
+	for _, p := range ean13Prefixes {
+		total += p[1] - p[0] + 1
+	}
+
+	n := r.Intn(total)
+	for _, p := range ean13Prefixes {
+		if size := p[1] - p[0] + 1; n >= size {
+			n -= size
+			continue
+		}
+		return gs1Number(r, fmt.Sprintf("%03d", p[0]+n), 13)
+	}
+	panic("unreachable")
+}
+
+// UPC generates a 12 digit UPC-A code.
+func UPC(r *rand.Rand) string {
+	return gs1Number(r, string("01678"[r.Intn(5)]), 12)
+}
+
+// ISBN10 generates a 10 digit International Standard Book Number, whose check
+// digit may be X.
+func ISBN10(r *rand.Rand) string {
+	b := randomDigits(r, make([]byte, 0, 10), 9)
+
+	sum := 0
+	for i, c := range b {
+		sum += (10 - i) * int(c-'0')
+	}
+	if check := (11 - sum%11) % 11; check == 10 {
+		b = append(b, 'X')
+	} else {
+		b = append(b, byte('0'+check))
+	}
+	return string(b)
+}
+
+// ISBN13 generates a 13 digit International Standard Book Number in the 978
+// prefix.
+func ISBN13(r *rand.Rand) string {
+	return gs1Number(r, "978", 13)
+}
+
+// gs1Number fills the prefix with random digits up to the given length,
+// ending in a GS1 check digit.
+func gs1Number(r *rand.Rand, prefix string, length int) string {
+	b := randomDigits(r, []byte(prefix), length-1-len(prefix))
+	return string(append(b, gs1CheckDigit(string(b))))
+}
+
+// gs1CheckDigit computes the digit that completes s as a GS1 number, such as
+// an EAN-13, UPC-A or ISBN-13.
+func gs1CheckDigit(s string) byte {
+	sum := 0
+	for i := len(s) - 1; i >= 0; i-- {
+		d := int(s[i] - '0')
+		if (len(s)-1-i)%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+func randomDigits(r *rand.Rand, b []byte, n int) []byte {
+	for i := 0; i < n; i++ {
+		b = append(b, byte('0'+r.Intn(10)))
+	}
+	return b
+}
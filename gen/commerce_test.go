@@ -0,0 +1,124 @@
+package gen
+
+import (
+	"math/rand"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestGS1CheckDigit(t *testing.T) {
+	tests := []struct {
+		s    string
+		want byte
+	}{
+		{s: "400638133393", want: '1'},
+		{s: "03600029145", want: '2'},
+		{s: "978030640615", want: '7'},
+		{s: "0", want: '0'},
+	}
+	for _, tt := range tests {
+		if got := gs1CheckDigit(tt.s); got != tt.want {
+			t.Errorf("gs1CheckDigit(%s) = %c, want %c", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestProductCodes(t *testing.T) {
+	tests := []struct {
+		name  string
+		f     func(r *rand.Rand) string
+		re    *regexp.Regexp
+		valid func(s string) bool
+	}{
+		{name: "EAN13", f: EAN13, re: regexp.MustCompile(`^(0[0135-9]\d|1\d\d|[3-8]\d\d|9[0-6]\d)\d{10}$`), valid: gs1Valid},
+		{name: "UPC", f: UPC, re: regexp.MustCompile(`^[01678]\d{11}$`), valid: gs1Valid},
+		{name: "ISBN13", f: ISBN13, re: regexp.MustCompile(`^978\d{10}$`), valid: gs1Valid},
+		{name: "ISBN10", f: ISBN10, re: regexp.MustCompile(`^\d{9}[\dX]$`), valid: isbn10Valid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(1))
+			for i := 0; i < 500; i++ {
+				if s := tt.f(r); !tt.re.MatchString(s) || !tt.valid(s) {
+					t.Fatalf("%s() = %s", tt.name, s)
+				}
+			}
+		})
+	}
+}
+
+func TestEAN13_Prefixes(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	seen := make(map[int]bool)
+	for i := 0; i < 20000; i++ {
+		prefix, _ := strconv.Atoi(EAN13(r)[:3])
+		if (prefix >= 20 && prefix <= 29) || (prefix >= 40 && prefix <= 49) || (prefix >= 200 && prefix <= 299) || prefix >= 970 {
+			t.Fatalf("EAN13() used the restricted prefix %03d", prefix)
+		}
+		seen[prefix] = true
+	}
+
+	// Every prefix outside the restricted ranges is used.
+	if len(seen) != 850 {
+		t.Errorf("EAN13() used %d prefixes, want 850", len(seen))
+	}
+}
+
+func TestPrice(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	re := regexp.MustCompile(`^\d{1,3}\.(99|95|49)$`)
+	for i := 0; i < 200; i++ {
+		if s := Price(r); !re.MatchString(s) {
+			t.Fatalf("Price() = %s", s)
+		}
+	}
+}
+
+func TestPriceBetween(t *testing.T) {
+	tests := []struct {
+		min, max float64
+		lo, hi   float64
+	}{
+		{min: 1, max: 2, lo: 1, hi: 2},
+		{min: 9.995, max: 10.02, lo: 10, hi: 10.02},
+		{min: 0.001, max: 0.009, lo: 0.01, hi: 0.01},
+		{min: 1.1, max: 1.1, lo: 1.1, hi: 1.1},
+	}
+	for _, tt := range tests {
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 100; i++ {
+			s := PriceBetween(r, tt.min, tt.max)
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil || f < tt.lo || f > tt.hi || !regexp.MustCompile(`^\d+\.\d{2}$`).MatchString(s) {
+				t.Fatalf("PriceBetween(%v, %v) = %s", tt.min, tt.max, s)
+			}
+		}
+	}
+}
+
+func TestJobTitle(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	re := regexp.MustCompile(`^([A-Z]\w+ )?[A-Z][\w ]+ [A-Z]\w+$`)
+	for i := 0; i < 100; i++ {
+		if s := JobTitle(r); !re.MatchString(s) {
+			t.Fatalf("JobTitle() = %s", s)
+		}
+	}
+}
+
+func gs1Valid(s string) bool {
+	return gs1CheckDigit(s[:len(s)-1]) == s[len(s)-1]
+}
+
+func isbn10Valid(s string) bool {
+	sum := 0
+	for i, c := range s {
+		d := int(c - '0')
+		if c == 'X' {
+			d = 10
+		}
+		sum += (10 - i) * d
+	}
+	return sum%11 == 0
+}
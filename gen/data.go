@@ -1,104 +1,107 @@
 package gen
 
-var firstNames = []string{
+var femaleNames = []string{
 	"Alice",
 	"Amanda",
 	"Amy",
-	"Andrew",
 	"Angela",
 	"Ann",
 	"Anna",
-	"Anthony",
-	"Arthur",
 	"Barbara",
 	"Betty",
 	"Brenda",
-	"Brian",
-	"Carl",
 	"Caroline",
 	"Catherine",
-	"Charles",
 	"Christine",
-	"Christopher",
 	"Cynthia",
-	"Daniel",
-	"David",
 	"Deborah",
 	"Debra",
-	"Dennis",
 	"Diane",
-	"Donald",
 	"Donna",
 	"Dorothy",
-	"Douglas",
-	"Edward",
 	"Elizabeth",
 	"Emily",
-	"Eric",
 	"Frances",
+	"Helen",
+	"Janet",
+	"Jennifer",
+	"Jessica",
+	"Joyce",
+	"Karen",
+	"Kathleen",
+	"Kimberly",
+	"Laura",
+	"Linda",
+	"Lisa",
+	"Margaret",
+	"Maria",
+	"Marie",
+	"Martha",
+	"Mary",
+	"Michelle",
+	"Nancy",
+	"Pamela",
+	"Patricia",
+	"Rebecca",
+	"Ruth",
+	"Sandra",
+	"Sarah",
+	"Sharon",
+	"Shirley",
+	"Stephanie",
+	"Susan",
+	"Virginia",
+}
+
+var maleNames = []string{
+	"Andrew",
+	"Anthony",
+	"Arthur",
+	"Brian",
+	"Carl",
+	"Charles",
+	"Christopher",
+	"Daniel",
+	"David",
+	"Dennis",
+	"Donald",
+	"Douglas",
+	"Edward",
+	"Eric",
 	"Frank",
 	"Gary",
 	"George",
 	"Gregory",
 	"Harold",
-	"Helen",
 	"Henry",
 	"Jack",
 	"James",
-	"Janet",
 	"Jason",
 	"Jeffrey",
-	"Jennifer",
 	"Jerry",
-	"Jessica",
 	"John",
 	"Jose",
 	"Joseph",
 	"Joshua",
-	"Joyce",
-	"Karen",
-	"Kathleen",
 	"Kenneth",
 	"Kevin",
-	"Kimberly",
 	"Larry",
-	"Laura",
-	"Linda",
-	"Lisa",
-	"Margaret",
-	"Maria",
-	"Marie",
 	"Mark",
-	"Martha",
-	"Mary",
 	"Matthew",
 	"Michael",
-	"Michelle",
-	"Nancy",
-	"Pamela",
-	"Patricia",
 	"Patrick",
 	"Paul",
 	"Peter",
 	"Raymond",
-	"Rebecca",
 	"Richard",
 	"Robert",
 	"Ronald",
-	"Ruth",
 	"Ryan",
-	"Sandra",
-	"Sarah",
 	"Scott",
-	"Sharon",
-	"Shirley",
-	"Stephanie",
 	"Stephen",
 	"Steven",
-	"Susan",
 	"Thomas",
 	"Timothy",
-	"Virginia",
 	"Walter",
 	"William",
 }
@@ -205,3 +208,1188 @@ var lastNames = []string{
 	"Foster",
 	"Jimenez",
 }
+
+var freeEmailDomains = []string{
+	"gmail.com",
+	"yahoo.com",
+	"hotmail.com",
+	"outlook.com",
+	"icloud.com",
+	"aol.com",
+	"proton.me",
+	"example.com",
+	"example.net",
+	"example.org",
+}
+
+var tlds = []string{
+	"com",
+	"net",
+	"org",
+	"io",
+	"co",
+	"dev",
+	"app",
+	"info",
+	"biz",
+	"us",
+}
+
+var internetWords = []string{
+	"acme",
+	"apex",
+	"atlas",
+	"beacon",
+	"blue",
+	"bright",
+	"cloud",
+	"cobalt",
+	"coral",
+	"crimson",
+	"delta",
+	"echo",
+	"ember",
+	"falcon",
+	"forge",
+	"frontier",
+	"galaxy",
+	"granite",
+	"harbor",
+	"horizon",
+	"indigo",
+	"iron",
+	"jade",
+	"juniper",
+	"keystone",
+	"kite",
+	"lumen",
+	"maple",
+	"meadow",
+	"metro",
+	"nimbus",
+	"north",
+	"nova",
+	"oak",
+	"orbit",
+	"pacific",
+	"pine",
+	"pixel",
+	"prairie",
+	"quartz",
+	"quest",
+	"river",
+	"rocket",
+	"sage",
+	"signal",
+	"silver",
+	"sky",
+	"solar",
+	"spark",
+	"summit",
+	"swift",
+	"terra",
+	"tidal",
+	"timber",
+	"trail",
+	"vertex",
+	"vista",
+	"wave",
+	"willow",
+	"zenith",
+}
+
+var userAgentPlatforms = []string{
+	"Windows NT 10.0; Win64; x64",
+	"Macintosh; Intel Mac OS X 10_15_7",
+	"X11; Linux x86_64",
+	"X11; Ubuntu; Linux x86_64",
+	"iPhone; CPU iPhone OS 16_5 like Mac OS X",
+	"Linux; Android 13; Pixel 7",
+}
+
+var places = []place{
+	{city: "New York", state: "New York", stateCode: "NY", zip: "100", lat: 40.7128, lng: -74.0060},
+	{city: "Los Angeles", state: "California", stateCode: "CA", zip: "900", lat: 34.0522, lng: -118.2437},
+	{city: "Chicago", state: "Illinois", stateCode: "IL", zip: "606", lat: 41.8781, lng: -87.6298},
+	{city: "Houston", state: "Texas", stateCode: "TX", zip: "770", lat: 29.7604, lng: -95.3698},
+	{city: "Phoenix", state: "Arizona", stateCode: "AZ", zip: "850", lat: 33.4484, lng: -112.0740},
+	{city: "Philadelphia", state: "Pennsylvania", stateCode: "PA", zip: "191", lat: 39.9526, lng: -75.1652},
+	{city: "San Antonio", state: "Texas", stateCode: "TX", zip: "782", lat: 29.4241, lng: -98.4936},
+	{city: "San Diego", state: "California", stateCode: "CA", zip: "921", lat: 32.7157, lng: -117.1611},
+	{city: "Dallas", state: "Texas", stateCode: "TX", zip: "752", lat: 32.7767, lng: -96.7970},
+	{city: "Austin", state: "Texas", stateCode: "TX", zip: "787", lat: 30.2672, lng: -97.7431},
+	{city: "Jacksonville", state: "Florida", stateCode: "FL", zip: "322", lat: 30.3322, lng: -81.6557},
+	{city: "Miami", state: "Florida", stateCode: "FL", zip: "331", lat: 25.7617, lng: -80.1918},
+	{city: "Columbus", state: "Ohio", stateCode: "OH", zip: "432", lat: 39.9612, lng: -82.9988},
+	{city: "Charlotte", state: "North Carolina", stateCode: "NC", zip: "282", lat: 35.2271, lng: -80.8431},
+	{city: "Indianapolis", state: "Indiana", stateCode: "IN", zip: "462", lat: 39.7684, lng: -86.1581},
+	{city: "Seattle", state: "Washington", stateCode: "WA", zip: "981", lat: 47.6062, lng: -122.3321},
+	{city: "Denver", state: "Colorado", stateCode: "CO", zip: "802", lat: 39.7392, lng: -104.9903},
+	{city: "Boston", state: "Massachusetts", stateCode: "MA", zip: "021", lat: 42.3601, lng: -71.0589},
+	{city: "Nashville", state: "Tennessee", stateCode: "TN", zip: "372", lat: 36.1627, lng: -86.7816},
+	{city: "Memphis", state: "Tennessee", stateCode: "TN", zip: "381", lat: 35.1495, lng: -90.0490},
+	{city: "Portland", state: "Oregon", stateCode: "OR", zip: "972", lat: 45.5152, lng: -122.6784},
+	{city: "Las Vegas", state: "Nevada", stateCode: "NV", zip: "891", lat: 36.1699, lng: -115.1398},
+	{city: "Detroit", state: "Michigan", stateCode: "MI", zip: "482", lat: 42.3314, lng: -83.0458},
+	{city: "Louisville", state: "Kentucky", stateCode: "KY", zip: "402", lat: 38.2527, lng: -85.7585},
+	{city: "Baltimore", state: "Maryland", stateCode: "MD", zip: "212", lat: 39.2904, lng: -76.6122},
+	{city: "Milwaukee", state: "Wisconsin", stateCode: "WI", zip: "532", lat: 43.0389, lng: -87.9065},
+	{city: "Albuquerque", state: "New Mexico", stateCode: "NM", zip: "871", lat: 35.0844, lng: -106.6504},
+	{city: "Atlanta", state: "Georgia", stateCode: "GA", zip: "303", lat: 33.7490, lng: -84.3880},
+	{city: "Kansas City", state: "Missouri", stateCode: "MO", zip: "641", lat: 39.0997, lng: -94.5786},
+	{city: "Minneapolis", state: "Minnesota", stateCode: "MN", zip: "554", lat: 44.9778, lng: -93.2650},
+	{city: "Salt Lake City", state: "Utah", stateCode: "UT", zip: "841", lat: 40.7608, lng: -111.8910},
+	{city: "Omaha", state: "Nebraska", stateCode: "NE", zip: "681", lat: 41.2565, lng: -95.9345},
+	{city: "Richmond", state: "Virginia", stateCode: "VA", zip: "232", lat: 37.5407, lng: -77.4360},
+	{city: "Boise", state: "Idaho", stateCode: "ID", zip: "837", lat: 43.6150, lng: -116.2023},
+	{city: "Des Moines", state: "Iowa", stateCode: "IA", zip: "503", lat: 41.5868, lng: -93.6250},
+}
+
+var streetNames = []string{
+	"Main",
+	"Oak",
+	"Pine",
+	"Maple",
+	"Cedar",
+	"Elm",
+	"Washington",
+	"Lake",
+	"Hill",
+	"Park",
+	"View",
+	"Sunset",
+	"Lincoln",
+	"Jackson",
+	"Church",
+	"Highland",
+	"River",
+	"Spring",
+	"Walnut",
+	"Chestnut",
+	"Meadow",
+	"Forest",
+	"Ridge",
+	"Franklin",
+	"Madison",
+	"Willow",
+	"Adams",
+	"Jefferson",
+	"Cherry",
+	"Mill",
+}
+
+var streetSuffixes = []string{
+	"Street",
+	"Avenue",
+	"Road",
+	"Lane",
+	"Drive",
+	"Court",
+	"Boulevard",
+	"Way",
+	"Place",
+	"Terrace",
+}
+
+var countries = []country{
+	{name: "United States", code: "US"},
+	{name: "Canada", code: "CA"},
+	{name: "Mexico", code: "MX"},
+	{name: "Brazil", code: "BR"},
+	{name: "Argentina", code: "AR"},
+	{name: "Chile", code: "CL"},
+	{name: "Colombia", code: "CO"},
+	{name: "Peru", code: "PE"},
+	{name: "United Kingdom", code: "GB"},
+	{name: "Ireland", code: "IE"},
+	{name: "France", code: "FR"},
+	{name: "Germany", code: "DE"},
+	{name: "Spain", code: "ES"},
+	{name: "Portugal", code: "PT"},
+	{name: "Italy", code: "IT"},
+	{name: "Netherlands", code: "NL"},
+	{name: "Belgium", code: "BE"},
+	{name: "Switzerland", code: "CH"},
+	{name: "Austria", code: "AT"},
+	{name: "Sweden", code: "SE"},
+	{name: "Norway", code: "NO"},
+	{name: "Denmark", code: "DK"},
+	{name: "Finland", code: "FI"},
+	{name: "Poland", code: "PL"},
+	{name: "Czechia", code: "CZ"},
+	{name: "Greece", code: "GR"},
+	{name: "Turkey", code: "TR"},
+	{name: "Egypt", code: "EG"},
+	{name: "Nigeria", code: "NG"},
+	{name: "Kenya", code: "KE"},
+	{name: "South Africa", code: "ZA"},
+	{name: "India", code: "IN"},
+	{name: "China", code: "CN"},
+	{name: "Japan", code: "JP"},
+	{name: "South Korea", code: "KR"},
+	{name: "Singapore", code: "SG"},
+	{name: "Indonesia", code: "ID"},
+	{name: "Thailand", code: "TH"},
+	{name: "Vietnam", code: "VN"},
+	{name: "Australia", code: "AU"},
+	{name: "New Zealand", code: "NZ"},
+}
+
+var loremWords = []string{
+	"a",
+	"ab",
+	"accusamus",
+	"accusantium",
+	"ad",
+	"adipisci",
+	"adipiscing",
+	"alias",
+	"aliqua",
+	"aliquam",
+	"aliquid",
+	"aliquip",
+	"amet",
+	"anim",
+	"animi",
+	"aperiam",
+	"architecto",
+	"asperiores",
+	"aspernatur",
+	"assumenda",
+	"at",
+	"atque",
+	"aut",
+	"aute",
+	"autem",
+	"beatae",
+	"blanditiis",
+	"cillum",
+	"commodi",
+	"commodo",
+	"consectetur",
+	"consequat",
+	"consequatur",
+	"consequuntur",
+	"corporis",
+	"corrupti",
+	"culpa",
+	"cum",
+	"cupidatat",
+	"cupiditate",
+	"debitis",
+	"delectus",
+	"deleniti",
+	"deserunt",
+	"dicta",
+	"dignissimos",
+	"dolor",
+	"dolore",
+	"dolorem",
+	"doloremque",
+	"dolores",
+	"doloribus",
+	"dolorum",
+	"ducimus",
+	"duis",
+	"ea",
+	"eaque",
+	"earum",
+	"eius",
+	"eiusmod",
+	"eligendi",
+	"enim",
+	"eos",
+	"error",
+	"esse",
+	"est",
+	"et",
+	"eum",
+	"eveniet",
+	"ex",
+	"excepteur",
+	"exercitation",
+	"exercitationem",
+	"expedita",
+	"explicabo",
+	"facere",
+	"facilis",
+	"fugiat",
+	"fugit",
+	"harum",
+	"hic",
+	"id",
+	"illo",
+	"illum",
+	"impedit",
+	"in",
+	"incididunt",
+	"incidunt",
+	"inventore",
+	"ipsa",
+	"ipsam",
+	"ipsum",
+	"irure",
+	"iste",
+	"itaque",
+	"iure",
+	"iusto",
+	"labore",
+	"laboriosam",
+	"laboris",
+	"laborum",
+	"laudantium",
+	"libero",
+	"magna",
+	"magnam",
+	"maiores",
+	"maxime",
+	"minim",
+	"minima",
+	"minus",
+	"modi",
+	"molestiae",
+	"molestias",
+	"mollit",
+	"mollitia",
+	"nam",
+	"natus",
+	"necessitatibus",
+	"nemo",
+	"neque",
+	"nesciunt",
+	"nihil",
+	"nisi",
+	"nobis",
+	"non",
+	"nostrud",
+	"nostrum",
+	"nulla",
+	"numquam",
+	"obcaecati",
+	"occaecat",
+	"odio",
+	"odit",
+	"officia",
+	"officiis",
+	"omnis",
+	"optio",
+	"pariatur",
+	"perferendis",
+	"perspiciatis",
+	"placeat",
+	"porro",
+	"possimus",
+	"praesentium",
+	"proident",
+	"provident",
+	"quae",
+	"quaerat",
+	"quam",
+	"quas",
+	"quasi",
+	"qui",
+	"quia",
+	"quibusdam",
+	"quidem",
+	"quis",
+	"quisquam",
+	"quo",
+	"quod",
+	"quos",
+	"ratione",
+	"recusandae",
+	"reiciendis",
+	"rem",
+	"repellat",
+	"repellendus",
+	"reprehenderit",
+	"repudiandae",
+	"rerum",
+	"saepe",
+	"sapiente",
+	"sed",
+	"sequi",
+	"similique",
+	"sint",
+	"sit",
+	"soluta",
+	"sunt",
+	"suscipit",
+	"tempor",
+	"tempora",
+	"tempore",
+	"temporibus",
+	"tenetur",
+	"totam",
+	"ullam",
+	"ullamco",
+	"unde",
+	"ut",
+	"vel",
+	"velit",
+	"veniam",
+	"veritatis",
+	"vero",
+	"vitae",
+	"voluptas",
+	"voluptate",
+	"voluptatem",
+	"voluptates",
+	"voluptatibus",
+	"voluptatum",
+}
+
+var cardNetworks = []cardNetwork{
+	{name: "visa", prefixes: [][2]int{{4, 4}}, length: 16},
+	{name: "mastercard", prefixes: [][2]int{{51, 55}, {2221, 2720}}, length: 16},
+	{name: "amex", prefixes: [][2]int{{34, 34}, {37, 37}}, length: 15},
+	{name: "discover", prefixes: [][2]int{{6011, 6011}, {644, 649}, {65, 65}}, length: 16},
+	{name: "jcb", prefixes: [][2]int{{3528, 3589}}, length: 16},
+	{name: "dinersclub", prefixes: [][2]int{{300, 305}, {36, 36}, {38, 39}}, length: 14},
+	{name: "unionpay", prefixes: [][2]int{{62, 62}}, length: 16},
+}
+
+// ibanFormats describe the basic bank account number of each country as a
+// sequence of lengths and character classes: n for digits, a for upper case
+// letters and c for either.
+var ibanFormats = []ibanFormat{
+	{country: "AT", bban: "5n11n"},
+	{country: "BE", bban: "3n7n2n"},
+	{country: "CH", bban: "5n12c"},
+	{country: "DE", bban: "8n10n"},
+	{country: "DK", bban: "4n9n1n"},
+	{country: "ES", bban: "4n4n1n1n10n"},
+	{country: "FI", bban: "3n11n"},
+	{country: "FR", bban: "5n5n11c2n"},
+	{country: "GB", bban: "4a6n8n"},
+	{country: "IE", bban: "4a6n8n"},
+	{country: "IT", bban: "1a5n5n12c"},
+	{country: "LU", bban: "3n13c"},
+	{country: "NL", bban: "4a10n"},
+	{country: "NO", bban: "4n6n1n"},
+	{country: "PL", bban: "8n16n"},
+	{country: "PT", bban: "4n4n11n2n"},
+	{country: "SE", bban: "3n16n1n"},
+}
+
+var currencies = []currency{
+	{code: "USD", digits: 2},
+	{code: "EUR", digits: 2},
+	{code: "GBP", digits: 2},
+	{code: "JPY", digits: 0},
+	{code: "CHF", digits: 2},
+	{code: "CAD", digits: 2},
+	{code: "AUD", digits: 2},
+	{code: "NZD", digits: 2},
+	{code: "CNY", digits: 2},
+	{code: "HKD", digits: 2},
+	{code: "SGD", digits: 2},
+	{code: "INR", digits: 2},
+	{code: "BRL", digits: 2},
+	{code: "MXN", digits: 2},
+	{code: "ZAR", digits: 2},
+	{code: "SEK", digits: 2},
+	{code: "NOK", digits: 2},
+	{code: "DKK", digits: 2},
+	{code: "PLN", digits: 2},
+	{code: "CZK", digits: 2},
+	{code: "KRW", digits: 0},
+	{code: "ISK", digits: 0},
+	{code: "CLP", digits: 0},
+	{code: "VND", digits: 0},
+	{code: "KWD", digits: 3},
+	{code: "BHD", digits: 3},
+	{code: "OMR", digits: 3},
+	{code: "JOD", digits: 3},
+}
+
+var deFemaleNames = []string{
+	"Sophie",
+	"Marie",
+	"Emma",
+	"Mia",
+	"Hannah",
+	"Lena",
+	"Lea",
+	"Anna",
+	"Lina",
+	"Clara",
+	"Greta",
+	"Katharina",
+	"Sabine",
+	"Ursula",
+	"Jana",
+}
+
+var deMaleNames = []string{
+	"Lukas",
+	"Leon",
+	"Finn",
+	"Jonas",
+	"Paul",
+	"Felix",
+	"Maximilian",
+	"Elias",
+	"Noah",
+	"Ben",
+	"Emil",
+	"Anton",
+	"Jürgen",
+	"Klaus",
+	"Stefan",
+	"Jörg",
+	"Björn",
+}
+
+var deLastNames = []string{
+	"Müller",
+	"Schmidt",
+	"Schneider",
+	"Fischer",
+	"Weber",
+	"Meyer",
+	"Wagner",
+	"Becker",
+	"Schulz",
+	"Hoffmann",
+	"Schäfer",
+	"Koch",
+	"Bauer",
+	"Richter",
+	"Klein",
+	"Wolf",
+	"Schröder",
+	"Neumann",
+	"Schwarz",
+	"Zimmermann",
+	"Braun",
+	"Krüger",
+	"Hofmann",
+	"Hartmann",
+	"Lange",
+	"Schmitt",
+	"Werner",
+	"Krause",
+	"Meier",
+	"Lehmann",
+}
+
+var dePlaces = []place{
+	{city: "Berlin", state: "Berlin", stateCode: "BE", zip: "10", lat: 52.5200, lng: 13.4050},
+	{city: "Hamburg", state: "Hamburg", stateCode: "HH", zip: "20", lat: 53.5511, lng: 9.9937},
+	{city: "München", state: "Bayern", stateCode: "BY", zip: "80", lat: 48.1351, lng: 11.5820},
+	{city: "Köln", state: "Nordrhein-Westfalen", stateCode: "NW", zip: "50", lat: 50.9375, lng: 6.9603},
+	{city: "Frankfurt am Main", state: "Hessen", stateCode: "HE", zip: "60", lat: 50.1109, lng: 8.6821},
+	{city: "Stuttgart", state: "Baden-Württemberg", stateCode: "BW", zip: "70", lat: 48.7758, lng: 9.1829},
+	{city: "Düsseldorf", state: "Nordrhein-Westfalen", stateCode: "NW", zip: "40", lat: 51.2277, lng: 6.7735},
+	{city: "Leipzig", state: "Sachsen", stateCode: "SN", zip: "04", lat: 51.3397, lng: 12.3731},
+	{city: "Dortmund", state: "Nordrhein-Westfalen", stateCode: "NW", zip: "44", lat: 51.5136, lng: 7.4653},
+	{city: "Essen", state: "Nordrhein-Westfalen", stateCode: "NW", zip: "45", lat: 51.4556, lng: 7.0116},
+	{city: "Bremen", state: "Bremen", stateCode: "HB", zip: "28", lat: 53.0793, lng: 8.8017},
+	{city: "Dresden", state: "Sachsen", stateCode: "SN", zip: "01", lat: 51.0504, lng: 13.7373},
+	{city: "Hannover", state: "Niedersachsen", stateCode: "NI", zip: "30", lat: 52.3759, lng: 9.7320},
+	{city: "Nürnberg", state: "Bayern", stateCode: "BY", zip: "90", lat: 49.4521, lng: 11.0767},
+	{city: "Kiel", state: "Schleswig-Holstein", stateCode: "SH", zip: "24", lat: 54.3233, lng: 10.1228},
+}
+
+var deStreetNames = []string{
+	"Hauptstraße",
+	"Bahnhofstraße",
+	"Gartenweg",
+	"Lindenallee",
+	"Schillerstraße",
+	"Goetheplatz",
+	"Kirchgasse",
+	"Am Markt",
+	"Waldweg",
+	"Bergstraße",
+	"Friedrichstraße",
+	"Mozartstraße",
+	"Rosenweg",
+	"Ringstraße",
+	"Schulstraße",
+	"Königsallee",
+}
+
+var frFemaleNames = []string{
+	"Marie",
+	"Camille",
+	"Léa",
+	"Chloé",
+	"Manon",
+	"Emma",
+	"Inès",
+	"Jade",
+	"Louise",
+	"Zoé",
+	"Hélène",
+	"Élodie",
+	"Céline",
+	"Margaux",
+	"Anaïs",
+}
+
+var frMaleNames = []string{
+	"Jean",
+	"Pierre",
+	"Michel",
+	"Louis",
+	"Lucas",
+	"Hugo",
+	"Léo",
+	"Gabriel",
+	"Arthur",
+	"Théo",
+	"Nathan",
+	"Raphaël",
+	"Jules",
+	"François",
+	"René",
+}
+
+var frLastNames = []string{
+	"Martin",
+	"Bernard",
+	"Dubois",
+	"Thomas",
+	"Robert",
+	"Richard",
+	"Petit",
+	"Durand",
+	"Leroy",
+	"Moreau",
+	"Simon",
+	"Laurent",
+	"Lefèvre",
+	"Michel",
+	"Garcia",
+	"David",
+	"Bertrand",
+	"Roux",
+	"Vincent",
+	"Fournier",
+	"Morel",
+	"Girard",
+	"André",
+	"Lefebvre",
+	"Mercier",
+	"Dupont",
+	"Lambert",
+	"Bonnet",
+	"François",
+	"Martinez",
+}
+
+var frPlaces = []place{
+	{city: "Paris", state: "Île-de-France", stateCode: "IDF", zip: "75", lat: 48.8566, lng: 2.3522},
+	{city: "Marseille", state: "Provence-Alpes-Côte d'Azur", stateCode: "PAC", zip: "13", lat: 43.2965, lng: 5.3698},
+	{city: "Lyon", state: "Auvergne-Rhône-Alpes", stateCode: "ARA", zip: "69", lat: 45.7640, lng: 4.8357},
+	{city: "Toulouse", state: "Occitanie", stateCode: "OCC", zip: "31", lat: 43.6047, lng: 1.4442},
+	{city: "Nice", state: "Provence-Alpes-Côte d'Azur", stateCode: "PAC", zip: "06", lat: 43.7102, lng: 7.2620},
+	{city: "Nantes", state: "Pays de la Loire", stateCode: "PDL", zip: "44", lat: 47.2184, lng: -1.5536},
+	{city: "Strasbourg", state: "Grand Est", stateCode: "GES", zip: "67", lat: 48.5734, lng: 7.7521},
+	{city: "Montpellier", state: "Occitanie", stateCode: "OCC", zip: "34", lat: 43.6108, lng: 3.8767},
+	{city: "Bordeaux", state: "Nouvelle-Aquitaine", stateCode: "NAQ", zip: "33", lat: 44.8378, lng: -0.5792},
+	{city: "Lille", state: "Hauts-de-France", stateCode: "HDF", zip: "59", lat: 50.6292, lng: 3.0573},
+	{city: "Rennes", state: "Bretagne", stateCode: "BRE", zip: "35", lat: 48.1173, lng: -1.6778},
+	{city: "Reims", state: "Grand Est", stateCode: "GES", zip: "51", lat: 49.2583, lng: 4.0317},
+	{city: "Dijon", state: "Bourgogne-Franche-Comté", stateCode: "BFC", zip: "21", lat: 47.3220, lng: 5.0415},
+	{city: "Rouen", state: "Normandie", stateCode: "NOR", zip: "76", lat: 49.4432, lng: 1.0999},
+	{city: "Tours", state: "Centre-Val de Loire", stateCode: "CVL", zip: "37", lat: 47.3941, lng: 0.6848},
+}
+
+var frStreetNames = []string{
+	"rue de la Paix",
+	"avenue des Champs-Élysées",
+	"boulevard Saint-Germain",
+	"rue Victor Hugo",
+	"place de la République",
+	"rue du Général de Gaulle",
+	"avenue Jean Jaurès",
+	"rue de la Gare",
+	"chemin des Vignes",
+	"rue Pasteur",
+	"allée des Tilleuls",
+	"rue de l'Église",
+	"impasse des Lilas",
+	"quai de la Loire",
+	"rue Nationale",
+}
+
+var jaFemaleNames = []string{
+	"結衣",
+	"陽菜",
+	"さくら",
+	"美咲",
+	"葵",
+	"凛",
+	"結菜",
+	"花子",
+	"愛子",
+	"七海",
+	"美月",
+	"彩",
+	"優奈",
+	"真央",
+	"芽依",
+	"恵",
+}
+
+var jaMaleNames = []string{
+	"翔太",
+	"大輝",
+	"蓮",
+	"悠斗",
+	"陽翔",
+	"湊",
+	"健太",
+	"拓海",
+	"大和",
+	"颯太",
+	"翼",
+	"誠",
+}
+
+var jaLastNames = []string{
+	"佐藤",
+	"鈴木",
+	"高橋",
+	"田中",
+	"伊藤",
+	"渡辺",
+	"山本",
+	"中村",
+	"小林",
+	"加藤",
+	"吉田",
+	"山田",
+	"佐々木",
+	"山口",
+	"松本",
+	"井上",
+	"木村",
+	"林",
+	"斎藤",
+	"清水",
+}
+
+// jaRomaji spells the Japanese names in the Latin alphabet, for use in email
+// addresses.
+var jaRomaji = map[string]string{
+	"翔太":  "shota",
+	"大輝":  "daiki",
+	"蓮":   "ren",
+	"悠斗":  "yuto",
+	"陽翔":  "haruto",
+	"湊":   "minato",
+	"健太":  "kenta",
+	"拓海":  "takumi",
+	"大和":  "yamato",
+	"颯太":  "sota",
+	"翼":   "tsubasa",
+	"誠":   "makoto",
+	"結衣":  "yui",
+	"陽菜":  "hina",
+	"さくら": "sakura",
+	"美咲":  "misaki",
+	"葵":   "aoi",
+	"凛":   "rin",
+	"結菜":  "yuna",
+	"花子":  "hanako",
+	"愛子":  "aiko",
+	"七海":  "nanami",
+	"美月":  "mizuki",
+	"彩":   "aya",
+	"優奈":  "yuna",
+	"真央":  "mao",
+	"芽依":  "mei",
+	"恵":   "megumi",
+	"佐藤":  "sato",
+	"鈴木":  "suzuki",
+	"高橋":  "takahashi",
+	"田中":  "tanaka",
+	"伊藤":  "ito",
+	"渡辺":  "watanabe",
+	"山本":  "yamamoto",
+	"中村":  "nakamura",
+	"小林":  "kobayashi",
+	"加藤":  "kato",
+	"吉田":  "yoshida",
+	"山田":  "yamada",
+	"佐々木": "sasaki",
+	"山口":  "yamaguchi",
+	"松本":  "matsumoto",
+	"井上":  "inoue",
+	"木村":  "kimura",
+	"林":   "hayashi",
+	"斎藤":  "saito",
+	"清水":  "shimizu",
+}
+
+var jaPlaces = []place{
+	{city: "千代田区", state: "東京都", stateCode: "13", zip: "100", lat: 35.6940, lng: 139.7536},
+	{city: "新宿区", state: "東京都", stateCode: "13", zip: "160", lat: 35.6938, lng: 139.7034},
+	{city: "渋谷区", state: "東京都", stateCode: "13", zip: "150", lat: 35.6640, lng: 139.6982},
+	{city: "横浜市", state: "神奈川県", stateCode: "14", zip: "220", lat: 35.4437, lng: 139.6380},
+	{city: "大阪市", state: "大阪府", stateCode: "27", zip: "530", lat: 34.6937, lng: 135.5023},
+	{city: "名古屋市", state: "愛知県", stateCode: "23", zip: "450", lat: 35.1815, lng: 136.9066},
+	{city: "札幌市", state: "北海道", stateCode: "01", zip: "060", lat: 43.0618, lng: 141.3545},
+	{city: "福岡市", state: "福岡県", stateCode: "40", zip: "810", lat: 33.5904, lng: 130.4017},
+	{city: "神戸市", state: "兵庫県", stateCode: "28", zip: "650", lat: 34.6901, lng: 135.1955},
+	{city: "京都市", state: "京都府", stateCode: "26", zip: "600", lat: 35.0116, lng: 135.7681},
+	{city: "仙台市", state: "宮城県", stateCode: "04", zip: "980", lat: 38.2682, lng: 140.8694},
+	{city: "広島市", state: "広島県", stateCode: "34", zip: "730", lat: 34.3853, lng: 132.4553},
+	{city: "さいたま市", state: "埼玉県", stateCode: "11", zip: "330", lat: 35.8617, lng: 139.6455},
+	{city: "千葉市", state: "千葉県", stateCode: "12", zip: "260", lat: 35.6074, lng: 140.1065},
+}
+
+var jaStreetNames = []string{
+	"本町",
+	"中央",
+	"栄町",
+	"旭町",
+	"緑町",
+	"桜木町",
+	"東町",
+	"西町",
+	"南町",
+	"北町",
+	"大手町",
+	"丸の内",
+	"青葉",
+	"錦",
+}
+
+var ptFemaleNames = []string{
+	"Maria",
+	"Ana",
+	"Juliana",
+	"Fernanda",
+	"Beatriz",
+	"Camila",
+	"Larissa",
+	"Letícia",
+	"Mariana",
+	"Gabriela",
+	"Luana",
+	"Bruna",
+	"Vitória",
+	"Júlia",
+	"Patrícia",
+}
+
+var ptMaleNames = []string{
+	"João",
+	"Pedro",
+	"Lucas",
+	"Gabriel",
+	"Matheus",
+	"Rafael",
+	"Gustavo",
+	"Felipe",
+	"Guilherme",
+	"Bruno",
+	"Thiago",
+	"André",
+	"José",
+	"Antônio",
+	"Luís",
+}
+
+var ptLastNames = []string{
+	"Silva",
+	"Santos",
+	"Oliveira",
+	"Souza",
+	"Rodrigues",
+	"Ferreira",
+	"Alves",
+	"Pereira",
+	"Lima",
+	"Gomes",
+	"Costa",
+	"Ribeiro",
+	"Martins",
+	"Carvalho",
+	"Almeida",
+	"Lopes",
+	"Soares",
+	"Fernandes",
+	"Vieira",
+	"Barbosa",
+	"Rocha",
+	"Dias",
+	"Nascimento",
+	"Andrade",
+	"Moreira",
+	"Nunes",
+	"Marques",
+	"Machado",
+	"Mendes",
+	"Freitas",
+	"Araújo",
+	"Conceição",
+}
+
+var ptPlaces = []place{
+	{city: "São Paulo", state: "São Paulo", stateCode: "SP", zip: "01", lat: -23.5505, lng: -46.6333},
+	{city: "Rio de Janeiro", state: "Rio de Janeiro", stateCode: "RJ", zip: "20", lat: -22.9068, lng: -43.1729},
+	{city: "Brasília", state: "Distrito Federal", stateCode: "DF", zip: "70", lat: -15.7939, lng: -47.8828},
+	{city: "Salvador", state: "Bahia", stateCode: "BA", zip: "40", lat: -12.9777, lng: -38.5016},
+	{city: "Fortaleza", state: "Ceará", stateCode: "CE", zip: "60", lat: -3.7319, lng: -38.5267},
+	{city: "Belo Horizonte", state: "Minas Gerais", stateCode: "MG", zip: "30", lat: -19.9167, lng: -43.9345},
+	{city: "Manaus", state: "Amazonas", stateCode: "AM", zip: "69", lat: -3.1190, lng: -60.0217},
+	{city: "Curitiba", state: "Paraná", stateCode: "PR", zip: "80", lat: -25.4284, lng: -49.2733},
+	{city: "Recife", state: "Pernambuco", stateCode: "PE", zip: "50", lat: -8.0476, lng: -34.8770},
+	{city: "Porto Alegre", state: "Rio Grande do Sul", stateCode: "RS", zip: "90", lat: -30.0346, lng: -51.2177},
+	{city: "Belém", state: "Pará", stateCode: "PA", zip: "66", lat: -1.4558, lng: -48.4902},
+	{city: "Goiânia", state: "Goiás", stateCode: "GO", zip: "74", lat: -16.6869, lng: -49.2648},
+	{city: "Campinas", state: "São Paulo", stateCode: "SP", zip: "13", lat: -22.9099, lng: -47.0626},
+	{city: "Florianópolis", state: "Santa Catarina", stateCode: "SC", zip: "88", lat: -27.5954, lng: -48.5480},
+}
+
+var ptStreetNames = []string{
+	"Rua das Flores",
+	"Avenida Paulista",
+	"Rua Augusta",
+	"Avenida Brasil",
+	"Rua XV de Novembro",
+	"Rua da Consolação",
+	"Avenida Atlântica",
+	"Rua São João",
+	"Rua Sete de Setembro",
+	"Avenida Getúlio Vargas",
+	"Rua Dom Pedro II",
+	"Travessa do Comércio",
+	"Rua Tiradentes",
+	"Alameda Santos",
+	"Praça da Sé",
+}
+
+var companySuffixes = []string{
+	"Inc.",
+	"LLC",
+	"Ltd.",
+	"Corp.",
+	"Group",
+	"Holdings",
+	"Partners",
+	"and Sons",
+	"Industries",
+	"Labs",
+}
+
+var catchPhraseAdjectives = []string{
+	"Adaptive",
+	"Automated",
+	"Balanced",
+	"Centralized",
+	"Cross-platform",
+	"Customizable",
+	"Decentralized",
+	"Distributed",
+	"Ergonomic",
+	"Expanded",
+	"Focused",
+	"Innovative",
+	"Integrated",
+	"Intuitive",
+	"Multi-layered",
+	"Optimized",
+	"Proactive",
+	"Reactive",
+	"Robust",
+	"Seamless",
+	"Streamlined",
+	"Synergized",
+	"Universal",
+	"User-centric",
+}
+
+var catchPhraseDescriptors = []string{
+	"24/7",
+	"asynchronous",
+	"bottom-line",
+	"client-driven",
+	"client-server",
+	"context-sensitive",
+	"data-driven",
+	"dynamic",
+	"global",
+	"heuristic",
+	"high-level",
+	"interactive",
+	"mission-critical",
+	"modular",
+	"next generation",
+	"real-time",
+	"scalable",
+	"stateless",
+	"value-added",
+	"zero-defect",
+}
+
+var catchPhraseNouns = []string{
+	"ability",
+	"algorithm",
+	"architecture",
+	"benchmark",
+	"capability",
+	"circuit",
+	"database",
+	"framework",
+	"hub",
+	"infrastructure",
+	"initiative",
+	"interface",
+	"matrix",
+	"methodology",
+	"middleware",
+	"model",
+	"paradigm",
+	"platform",
+	"portal",
+	"solution",
+	"strategy",
+	"toolset",
+}
+
+var jobLevels = []string{
+	"Junior",
+	"Senior",
+	"Lead",
+	"Principal",
+	"Chief",
+	"Associate",
+	"Assistant",
+	"Head",
+}
+
+var jobAreas = []string{
+	"Accounts",
+	"Brand",
+	"Communications",
+	"Customer Success",
+	"Data",
+	"Finance",
+	"Human Resources",
+	"Infrastructure",
+	"Legal",
+	"Logistics",
+	"Marketing",
+	"Operations",
+	"Product",
+	"Quality",
+	"Research",
+	"Sales",
+	"Security",
+	"Software",
+}
+
+var jobRoles = []string{
+	"Administrator",
+	"Analyst",
+	"Architect",
+	"Consultant",
+	"Coordinator",
+	"Designer",
+	"Developer",
+	"Director",
+	"Engineer",
+	"Manager",
+	"Officer",
+	"Planner",
+	"Specialist",
+	"Strategist",
+	"Technician",
+}
+
+var departments = []string{
+	"Accounting",
+	"Customer Support",
+	"Design",
+	"Engineering",
+	"Facilities",
+	"Finance",
+	"Human Resources",
+	"Information Technology",
+	"Legal",
+	"Logistics",
+	"Marketing",
+	"Operations",
+	"Procurement",
+	"Product",
+	"Public Relations",
+	"Quality Assurance",
+	"Research and Development",
+	"Sales",
+	"Security",
+}
+
+var productAdjectives = []string{
+	"Awesome",
+	"Classic",
+	"Compact",
+	"Durable",
+	"Ergonomic",
+	"Fantastic",
+	"Gorgeous",
+	"Handcrafted",
+	"Incredible",
+	"Intelligent",
+	"Lightweight",
+	"Modern",
+	"Practical",
+	"Premium",
+	"Refined",
+	"Rustic",
+	"Sleek",
+	"Small",
+}
+
+var productMaterials = []string{
+	"Bamboo",
+	"Bronze",
+	"Ceramic",
+	"Concrete",
+	"Cotton",
+	"Glass",
+	"Granite",
+	"Leather",
+	"Linen",
+	"Marble",
+	"Plastic",
+	"Rubber",
+	"Silk",
+	"Steel",
+	"Wooden",
+	"Wool",
+}
+
+var productNouns = []string{
+	"Backpack",
+	"Ball",
+	"Bike",
+	"Bottle",
+	"Chair",
+	"Computer",
+	"Gloves",
+	"Hat",
+	"Keyboard",
+	"Lamp",
+	"Mouse",
+	"Mug",
+	"Pants",
+	"Shirt",
+	"Shoes",
+	"Table",
+	"Towels",
+	"Wallet",
+	"Watch",
+}
+
+var priceEndings = []string{
+	".99",
+	".95",
+	".49",
+}
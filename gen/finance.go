@@ -0,0 +1,221 @@
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// cardNetwork describes the numbers issued by a card network. Each prefix is
+// an inclusive range of issuer identification numbers.
+type cardNetwork struct {
+	name     string
+	prefixes [][2]int
+	length   int
+}
+
+// ibanFormat describes the account numbers of a country taking part in IBAN.
+type ibanFormat struct {
+	country string
+	bban    string
+}
+
+// currency is an ISO 4217 currency and the number of digits after its decimal
+// point.
+type currency struct {
+	code   string
+	digits int
+}
+
+// MonetaryAmount is an amount of money with as many decimal places as its
+// currency uses. The amount is kept as a string so that trailing zeros, as in
+// 12.50, survive encoding.
+type MonetaryAmount struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func CreditCard(r *rand.Rand) string {
+	return cardNumber(r, cardNetworks[r.Intn(len(cardNetworks))])
+}
+
+// CreditCardFor generates a card number issued by the named network: visa,
+// mastercard, amex, discover, jcb, dinersclub or unionpay.
+func CreditCardFor(r *rand.Rand, network string) (string, error) {
+	for _, n := range cardNetworks {
+		if strings.EqualFold(n.name, network) {
+			return cardNumber(r, n), nil
+		}
+	}
+	return "", fmt.Errorf("unknown card network %q", network)
+}
+
+// cardNumber generates a number with one of the network's prefixes, ending in
+// a Luhn check digit.
+func cardNumber(r *rand.Rand, n cardNetwork) string {
+	p := n.prefixes[r.Intn(len(n.prefixes))]
+
+	b := []byte(strconv.Itoa(p[0] + r.Intn(p[1]-p[0]+1)))
+	for len(b) < n.length-1 {
+		b = append(b, byte('0'+r.Intn(10)))
+	}
+	return string(append(b, luhnCheckDigit(string(b))))
+}
+
+// luhnCheckDigit computes the digit that makes s valid under the Luhn
+// algorithm when appended to it.
+func luhnCheckDigit(s string) byte {
+	sum := 0
+	for i := len(s) - 1; i >= 0; i-- {
+		d := int(s[i] - '0')
+		if (len(s)-1-i)%2 == 0 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+func IBAN(r *rand.Rand) string {
+	return iban(r, ibanFormats[r.Intn(len(ibanFormats))])
+}
+
+// IBANFor generates an IBAN for the country with the given ISO 3166 code.
+func IBANFor(r *rand.Rand, country string) (string, error) {
+	for _, f := range ibanFormats {
+		if strings.EqualFold(f.country, country) {
+			return iban(r, f), nil
+		}
+	}
+	return "", fmt.Errorf("unsupported IBAN country %q", country)
+}
+
+// iban generates an IBAN in the electronic format, without spaces. The IBAN
+// check digits are valid, but national check digits within the account number
+// are not computed.
+func iban(r *rand.Rand, f ibanFormat) string {
+	var bban strings.Builder
+	for spec := f.bban; spec != ""; {
+		i := strings.IndexAny(spec, "nac")
+		n, _ := strconv.Atoi(spec[:i])
+
+		chars := "0123456789"
+		switch spec[i] {
+		case 'a':
+			chars = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+		case 'c':
+			chars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+		}
+		for j := 0; j < n; j++ {
+			bban.WriteByte(chars[r.Intn(len(chars))])
+		}
+
+		spec = spec[i+1:]
+	}
+
+	return f.country + ibanCheckDigits(f.country, bban.String()) + bban.String()
+}
+
+// ibanCheckDigits computes the ISO 7064 mod 97-10 check digits of an IBAN.
+func ibanCheckDigits(country, bban string) string {
+	mod := 0
+	for _, c := range bban + country + "00" {
+		if c >= 'A' {
+			mod = (mod*100 + int(c-'A'+10)) % 97
+		} else {
+			mod = (mod*10 + int(c-'0')) % 97
+		}
+	}
+	return fmt.Sprintf("%02d", 98-mod)
+}
+
+func BIC(r *rand.Rand) string {
+	const letters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	const alnum = "0123456789" + letters
+
+	b := make([]byte, 0, 11)
+	for i := 0; i < 4; i++ {
+		b = append(b, letters[r.Intn(len(letters))])
+	}
+	b = append(b, countries[r.Intn(len(countries))].code...)
+	// A location code ending in 0 or 1 marks a test or passive BIC.
+	b = append(b, alnum[r.Intn(len(alnum))], alnum[2+r.Intn(len(alnum)-2)])
+
+	if r.Intn(2) == 1 {
+		for i := 0; i < 3; i++ {
+			b = append(b, alnum[r.Intn(len(alnum))])
+		}
+	}
+	return string(b)
+}
+
+// RoutingNumber generates an ABA routing transit number. The first two digits
+// identify a Federal Reserve district and the last is a check digit.
+func RoutingNumber(r *rand.Rand) string {
+	district := 1 + r.Intn(24)
+	if district > 12 {
+		// Thrift institutions use the district plus 20.
+		district += 8
+	}
+
+	d := make([]int, 9)
+	d[0], d[1] = district/10, district%10
+	for i := 2; i < 8; i++ {
+		d[i] = r.Intn(10)
+	}
+	sum := 3*(d[0]+d[3]+d[6]) + 7*(d[1]+d[4]+d[7]) + d[2] + d[5]
+	d[8] = (10 - sum%10) % 10
+
+	b := make([]byte, len(d))
+	for i := range d {
+		b[i] = byte('0' + d[i])
+	}
+	return string(b)
+}
+
+func CurrencyCode(r *rand.Rand) string {
+	return currencies[r.Intn(len(currencies))].code
+}
+
+// Amount generates an amount of money with two decimal places.
+func Amount(r *rand.Rand) string {
+	return amount(r, 2)
+}
+
+// AmountIn generates an amount of money with as many decimal places as the
+// currency with the given ISO 4217 code uses.
+func AmountIn(r *rand.Rand, code string) (string, error) {
+	for _, c := range currencies {
+		if strings.EqualFold(c.code, code) {
+			return amount(r, c.digits), nil
+		}
+	}
+	return "", fmt.Errorf("unknown currency %q", code)
+}
+
+func Money(r *rand.Rand) MonetaryAmount {
+	c := currencies[r.Intn(len(currencies))]
+	return MonetaryAmount{Amount: amount(r, c.digits), Currency: c.code}
+}
+
+// amount generates an amount below 100000 with the given number of decimal
+// places. The number of digits in the whole part is chosen first, so small
+// amounts are as common as large ones.
+func amount(r *rand.Rand, digits int) string {
+	whole := r.Intn(pow10(1 + r.Intn(5)))
+	if digits == 0 {
+		return strconv.Itoa(whole)
+	}
+	return fmt.Sprintf("%d.%0*d", whole, digits, r.Intn(pow10(digits)))
+}
+
+func pow10(n int) int {
+	p := 1
+	for i := 0; i < n; i++ {
+		p *= 10
+	}
+	return p
+}
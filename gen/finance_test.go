@@ -0,0 +1,201 @@
+package gen
+
+import (
+	"math/big"
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestLuhnCheckDigit(t *testing.T) {
+	tests := []struct {
+		s    string
+		want byte
+	}{
+		{s: "7992739871", want: '3'},
+		{s: "411111111111111", want: '1'},
+		{s: "37828224631000", want: '5'},
+		{s: "0", want: '0'},
+	}
+	for _, tt := range tests {
+		if got := luhnCheckDigit(tt.s); got != tt.want {
+			t.Errorf("luhnCheckDigit(%s) = %c, want %c", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestCreditCardFor(t *testing.T) {
+	tests := []struct {
+		network string
+		re      *regexp.Regexp
+	}{
+		{network: "visa", re: regexp.MustCompile(`^4\d{15}$`)},
+		{network: "mastercard", re: regexp.MustCompile(`^(5[1-5]\d{14}|2(22[1-9]|2[3-9]\d|[3-6]\d\d|7[01]\d|720)\d{12})$`)},
+		{network: "amex", re: regexp.MustCompile(`^3[47]\d{13}$`)},
+		{network: "discover", re: regexp.MustCompile(`^(6011|64[4-9]\d|65\d\d)\d{12}$`)},
+		{network: "jcb", re: regexp.MustCompile(`^35(2[89]|[3-8]\d)\d{12}$`)},
+		{network: "dinersclub", re: regexp.MustCompile(`^(30[0-5]\d|36\d\d|3[89]\d\d)\d{10}$`)},
+		{network: "UnionPay", re: regexp.MustCompile(`^62\d{14}$`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.network, func(t *testing.T) {
+			r := rand.New(rand.NewSource(1))
+			for i := 0; i < 100; i++ {
+				s, err := CreditCardFor(r, tt.network)
+				if err != nil {
+					t.Fatalf("CreditCardFor() error = %v", err)
+				}
+				if !tt.re.MatchString(s) || !luhnValid(s) {
+					t.Fatalf("CreditCardFor() = %s", s)
+				}
+			}
+		})
+	}
+
+	if _, err := CreditCardFor(rand.New(rand.NewSource(1)), "bank"); err == nil {
+		t.Error("CreditCardFor() with an unknown network, want error")
+	}
+}
+
+// luhnValid checks a number in the way a validator would, independently of
+// luhnCheckDigit.
+func luhnValid(s string) bool {
+	sum := 0
+	for i, c := range s {
+		d := int(c - '0')
+		if (len(s)-i)%2 == 0 {
+			d = d*2/10 + d*2%10
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+func TestIBAN(t *testing.T) {
+	if got := ibanCheckDigits("GB", "WEST12345698765432"); got != "82" {
+		t.Errorf("ibanCheckDigits() = %s, want 82", got)
+	}
+
+	lengths := map[string]int{"DE": 22, "GB": 22, "FR": 27, "NO": 15, "IT": 27}
+	r := rand.New(rand.NewSource(1))
+	for country, n := range lengths {
+		for i := 0; i < 50; i++ {
+			s, err := IBANFor(r, strings.ToLower(country))
+			if err != nil {
+				t.Fatalf("IBANFor() error = %v", err)
+			}
+			if len(s) != n || !strings.HasPrefix(s, country) || !ibanValid(s) {
+				t.Fatalf("IBANFor(%s) = %s", country, s)
+			}
+		}
+	}
+
+	for i := 0; i < 100; i++ {
+		if s := IBAN(r); !ibanValid(s) {
+			t.Fatalf("IBAN() = %s", s)
+		}
+	}
+
+	if _, err := IBANFor(r, "US"); err == nil {
+		t.Error("IBANFor() with an unsupported country, want error")
+	}
+}
+
+// ibanValid moves the first four characters to the end, converts letters to
+// numbers and checks the result is 1 mod 97.
+func ibanValid(s string) bool {
+	var digits strings.Builder
+	for _, c := range s[4:] + s[:4] {
+		if c >= 'A' && c <= 'Z' {
+			digits.WriteString(strconv.Itoa(int(c-'A') + 10))
+		} else {
+			digits.WriteRune(c)
+		}
+	}
+
+	n, ok := new(big.Int).SetString(digits.String(), 10)
+	return ok && new(big.Int).Mod(n, big.NewInt(97)).Int64() == 1
+}
+
+func TestBIC(t *testing.T) {
+	re := regexp.MustCompile(`^[A-Z]{4}[A-Z]{2}[A-Z0-9][A-Z2-9]([A-Z0-9]{3})?$`)
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		if s := BIC(r); !re.MatchString(s) {
+			t.Fatalf("BIC() = %s", s)
+		}
+	}
+}
+
+func TestRoutingNumber(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		s := RoutingNumber(r)
+		if len(s) != 9 {
+			t.Fatalf("RoutingNumber() = %s", s)
+		}
+
+		if district, _ := strconv.Atoi(s[:2]); district < 1 || (district > 12 && district < 21) || district > 32 {
+			t.Fatalf("RoutingNumber() = %s has an invalid district", s)
+		}
+
+		sum := 0
+		for j, c := range s {
+			sum += []int{3, 7, 1}[j%3] * int(c-'0')
+		}
+		if sum%10 != 0 {
+			t.Fatalf("RoutingNumber() = %s has an invalid check digit", s)
+		}
+	}
+}
+
+func TestAmountIn(t *testing.T) {
+	tests := []struct {
+		code string
+		re   *regexp.Regexp
+	}{
+		{code: "USD", re: regexp.MustCompile(`^\d{1,5}\.\d{2}$`)},
+		{code: "jpy", re: regexp.MustCompile(`^\d{1,5}$`)},
+		{code: "KWD", re: regexp.MustCompile(`^\d{1,5}\.\d{3}$`)},
+	}
+	for _, tt := range tests {
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 100; i++ {
+			s, err := AmountIn(r, tt.code)
+			if err != nil {
+				t.Fatalf("AmountIn() error = %v", err)
+			}
+			if !tt.re.MatchString(s) {
+				t.Fatalf("AmountIn(%s) = %s", tt.code, s)
+			}
+		}
+	}
+
+	if _, err := AmountIn(rand.New(rand.NewSource(1)), "XYZ"); err == nil {
+		t.Error("AmountIn() with an unknown currency, want error")
+	}
+}
+
+func TestMoney(t *testing.T) {
+	decimals := func(s string) int {
+		if i := strings.Index(s, "."); i >= 0 {
+			return len(s) - i - 1
+		}
+		return 0
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		m := Money(r)
+		want, err := AmountIn(rand.New(rand.NewSource(1)), m.Currency)
+		if err != nil {
+			t.Fatalf("Money() = %+v has an unknown currency", m)
+		}
+		if decimals(m.Amount) != decimals(want) {
+			t.Fatalf("Money() = %+v has the wrong number of decimal places", m)
+		}
+	}
+}
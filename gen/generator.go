@@ -1,40 +1,94 @@
+// Package gen provides the generators behind Sham's terminal generators.
+// Every generator draws its random decisions from the *rand.Rand passed as its
+// first argument, so seeded sources reproduce their output. Name, FirstName,
+// LastName, PhoneNumber and Timestamp predate this convention and draw from
+// the global source; their Rand suffixed variants follow it.
 package gen
 
 import (
 	"math/rand"
-	"strings"
 	"time"
 )
 
-func getRandomString(vals []string) string { return vals[rand.Intn(len(vals))] }
+var globalRand = rand.New(globalSource{})
 
+// globalSource is a rand.Source backed by the top level math/rand functions,
+// whose source is locked and so safe for concurrent use.
+type globalSource struct{}
+
+func (globalSource) Int63() int64    { return rand.Int63() }
+func (globalSource) Uint64() uint64  { return rand.Uint64() }
+func (globalSource) Seed(seed int64) { rand.Seed(seed) }
+
+// GlobalRand returns a *rand.Rand drawing from the top level math/rand
+// functions, so that generators given it follow rand.Seed. It is safe for
+// concurrent use, except for its Read method.
+func GlobalRand() *rand.Rand {
+	return globalRand
+}
+
+func getRandomString(r *rand.Rand, vals []string) string { return vals[r.Intn(len(vals))] }
+
+// Name generates a full name using the global source.
+//
+// Deprecated: Use NameRand, which takes the source like the other generators.
 func Name() string {
-	return getRandomString(firstNames) + " " + getRandomString(lastNames)
+	return NameRand(globalRand)
 }
 
+func NameRand(r *rand.Rand) string {
+	return DefaultLocale.Name(r)
+}
+
+// FirstName generates a first name using the global source.
+//
+// Deprecated: Use FirstNameRand, which takes the source like the other
+// generators.
 func FirstName() string {
-	return getRandomString(firstNames)
+	return FirstNameRand(globalRand)
+}
+
+func FirstNameRand(r *rand.Rand) string {
+	return DefaultLocale.FirstName(r)
 }
 
+// LastName generates a last name using the global source.
+//
+// Deprecated: Use LastNameRand, which takes the source like the other
+// generators.
 func LastName() string {
-	return getRandomString(lastNames)
+	return LastNameRand(globalRand)
 }
 
-func PhoneNumber() string {
-	const digits string = "1234567890"
-	var sb strings.Builder
+func LastNameRand(r *rand.Rand) string {
+	return DefaultLocale.LastName(r)
+}
 
-	for i := 0; i < 12; i++ {
-		if i == 3 || i == 7 {
-			sb.WriteRune('-')
-		} else {
-			sb.WriteByte(digits[rand.Intn(len(digits))])
-		}
-	}
+// PhoneNumber generates a phone number using the global source.
+//
+// Deprecated: Use PhoneNumberRand, which takes the source like the other
+// generators.
+func PhoneNumber() string {
+	return PhoneNumberRand(globalRand)
+}
 
-	return sb.String()
+func PhoneNumberRand(r *rand.Rand) string {
+	return DefaultLocale.PhoneNumber(r)
 }
 
+// Timestamp generates a time between 1970 and ReferenceTime using the global
+// source.
+//
+// Deprecated: Use TimestampRand, which takes the source like the other
+// generators.
 func Timestamp() time.Time {
-	return time.Unix(int64(rand.Intn(int(time.Now().Unix()))), 0)
+	return TimestampRand(globalRand)
+}
+
+func TimestampRand(r *rand.Rand) time.Time {
+	return time.Unix(int64(r.Intn(int(ReferenceTime.Unix()))), 0)
+}
+
+func Bool(r *rand.Rand) bool {
+	return r.Intn(2) == 1
 }
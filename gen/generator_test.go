@@ -0,0 +1,27 @@
+package gen
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestGlobalRand(t *testing.T) {
+	draw := func() []string {
+		var names []string
+		for i := 0; i < 5; i++ {
+			names = append(names, NameRand(GlobalRand()), Name())
+		}
+		return names
+	}
+
+	rand.Seed(1)
+	first := draw()
+	rand.Seed(1)
+	second := draw()
+
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("GlobalRand() does not follow rand.Seed: %v, then %v", first, second)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package gen
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+	"math/rand"
+	"time"
+)
+
+// Time ordered identifiers embed a timestamp drawn from the random source
+// between these times, rather than the current time, so that identifiers are
+// reproducible.
+var (
+	idTimeMin = time.Date(2015, time.January, 1, 0, 0, 0, 0, time.UTC)
+	idTimeMax = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func UUID(r *rand.Rand) string {
+	return UUIDv4(r)
+}
+
+func UUIDv4(r *rand.Rand) string {
+	b := randomBytes(r, 16)
+	return formatUUID(b, 4)
+}
+
+func UUIDv7(r *rand.Rand) string {
+	b := randomBytes(r, 16)
+	putMillis(b, idTime(r))
+	return formatUUID(b, 7)
+}
+
+// formatUUID sets the version and RFC 4122 variant bits and formats the UUID
+// in its canonical form.
+func formatUUID(b []byte, version byte) string {
+	b[6] = b[6]&0x0f | version<<4
+	b[8] = b[8]&0x3f | 0x80
+
+	buf := make([]byte, 36)
+	hex.Encode(buf[0:8], b[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], b[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], b[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], b[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], b[10:])
+	return string(buf)
+}
+
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+func ULID(r *rand.Rand) string {
+	b := randomBytes(r, 16)
+	putMillis(b, idTime(r))
+
+	// The 128 bits are encoded as 26 characters of 5 bits, the first of which
+	// only holds the 3 most significant bits.
+	hi, lo := binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])
+	out := make([]byte, 26)
+	for i := 25; i >= 0; i-- {
+		out[i] = crockford[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out)
+}
+
+const (
+	base62 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	// ksuidEpoch is the start of KSUID timestamps, in Unix seconds.
+	ksuidEpoch = 1400000000
+)
+
+func KSUID(r *rand.Rand) string {
+	b := randomBytes(r, 20)
+	binary.BigEndian.PutUint32(b, uint32(idTime(r).Unix()-ksuidEpoch))
+
+	n := new(big.Int).SetBytes(b)
+	base, mod := big.NewInt(62), new(big.Int)
+
+	out := make([]byte, 27)
+	for i := range out {
+		n.DivMod(n, base, mod)
+		out[len(out)-1-i] = base62[mod.Int64()]
+	}
+	return string(out)
+}
+
+const nanoidAlphabet = "_-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+func NanoID(r *rand.Rand) string {
+	return NanoIDOfLength(r, 21)
+}
+
+// NanoIDOfLength generates a Nano ID with n characters.
+func NanoIDOfLength(r *rand.Rand, n int) string {
+	out := make([]byte, n)
+	for i := range out {
+		out[i] = nanoidAlphabet[r.Intn(len(nanoidAlphabet))]
+	}
+	return string(out)
+}
+
+// idTime draws the timestamp of a time ordered identifier.
+func idTime(r *rand.Rand) time.Time {
+	span := idTimeMax.Sub(idTimeMin).Milliseconds()
+	return idTimeMin.Add(time.Duration(r.Int63n(span)) * time.Millisecond)
+}
+
+// putMillis stores the Unix time in milliseconds in the first 48 bits of b.
+func putMillis(b []byte, t time.Time) {
+	ms := uint64(t.UnixNano() / int64(time.Millisecond))
+	for i := 0; i < 6; i++ {
+		b[i] = byte(ms >> (40 - 8*i))
+	}
+}
+
+// randomBytes draws n bytes from the source. Unlike rand.Rand.Read, no state
+// is kept between calls, so the bytes only depend on the source.
+func randomBytes(r *rand.Rand, n int) []byte {
+	b := make([]byte, n)
+	for i := 0; i < n; i += 8 {
+		var chunk [8]byte
+		binary.LittleEndian.PutUint64(chunk[:], r.Uint64())
+		copy(b[i:], chunk[:])
+	}
+	return b
+}
@@ -0,0 +1,88 @@
+package gen
+
+import (
+	"math/big"
+	"math/rand"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUUID(t *testing.T) {
+	tests := []struct {
+		name string
+		gen  func(r *rand.Rand) string
+		re   *regexp.Regexp
+	}{
+		{name: "UUIDv4", gen: UUIDv4, re: regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)},
+		{name: "UUIDv7", gen: UUIDv7, re: regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(1))
+			for i := 0; i < 100; i++ {
+				if s := tt.gen(r); !tt.re.MatchString(s) {
+					t.Fatalf("%s() = %s", tt.name, s)
+				}
+			}
+		})
+	}
+
+	r := rand.New(rand.NewSource(1))
+	s := strings.ReplaceAll(UUIDv7(r), "-", "")
+	ms, _ := new(big.Int).SetString(s[:12], 16)
+	if ts := time.Unix(0, ms.Int64()*int64(time.Millisecond)); ts.Before(idTimeMin) || !ts.Before(idTimeMax) {
+		t.Errorf("UUIDv7() timestamp = %v", ts)
+	}
+}
+
+func TestULID(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		s := ULID(r)
+		if !regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`).MatchString(s) {
+			t.Fatalf("ULID() = %s", s)
+		}
+
+		var ms int64
+		for _, c := range s[:10] {
+			ms = ms<<5 | int64(strings.IndexRune(crockford, c))
+		}
+		if ts := time.Unix(0, ms*int64(time.Millisecond)); ts.Before(idTimeMin) || !ts.Before(idTimeMax) {
+			t.Fatalf("ULID() = %s has timestamp %v", s, ts)
+		}
+	}
+}
+
+func TestKSUID(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		s := KSUID(r)
+		if len(s) != 27 {
+			t.Fatalf("KSUID() = %s", s)
+		}
+
+		n := new(big.Int)
+		for _, c := range s {
+			n.Mul(n, big.NewInt(62))
+			n.Add(n, big.NewInt(int64(strings.IndexRune(base62, c))))
+		}
+		b := make([]byte, 20)
+		n.FillBytes(b)
+
+		secs := int64(b[0])<<24 | int64(b[1])<<16 | int64(b[2])<<8 | int64(b[3])
+		if ts := time.Unix(secs+ksuidEpoch, 0); ts.Before(idTimeMin) || !ts.Before(idTimeMax) {
+			t.Fatalf("KSUID() = %s has timestamp %v", s, ts)
+		}
+	}
+}
+
+func TestNanoID(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		if s := NanoID(r); !regexp.MustCompile(`^[A-Za-z0-9_-]{21}$`).MatchString(s) {
+			t.Fatalf("NanoID() = %s", s)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"strconv"
+	"strings"
+)
+
+func Email(r *rand.Rand) string {
+	return EmailAt(r, getRandomString(r, freeEmailDomains))
+}
+
+// EmailAt generates an email address at the given domain.
+func EmailAt(r *rand.Rand, domain string) string {
+	return localPart(r, FirstNameRand(r), LastNameRand(r)) + "@" + domain
+}
+
+// localPart builds the local part of an email address from a name in one of
+// the forms commonly used by real addresses.
+func localPart(r *rand.Rand, first, last string) string {
+	first, last = slugify(first), slugify(last)
+
+	switch r.Intn(4) {
+	case 0:
+		return first + "." + last
+	case 1:
+		return first[:1] + last
+	case 2:
+		return first + "_" + last + strconv.Itoa(r.Intn(100))
+	default:
+		return first + strconv.Itoa(r.Intn(1000))
+	}
+}
+
+func Username(r *rand.Rand) string {
+	first, last := slugify(FirstNameRand(r)), slugify(LastNameRand(r))
+
+	switch r.Intn(3) {
+	case 0:
+		return first + "_" + last
+	case 1:
+		return first[:1] + last + strconv.Itoa(r.Intn(100))
+	default:
+		return getRandomString(r, internetWords) + "_" + first + strconv.Itoa(r.Intn(1000))
+	}
+}
+
+func Domain(r *rand.Rand) string {
+	return DomainWithTLD(r, getRandomString(r, tlds))
+}
+
+// DomainWithTLD generates a domain name ending in the given top level domain.
+func DomainWithTLD(r *rand.Rand, tld string) string {
+	name := getRandomString(r, internetWords)
+	if r.Intn(2) == 0 {
+		name += "-" + getRandomString(r, internetWords)
+	}
+	return name + "." + strings.TrimPrefix(tld, ".")
+}
+
+func URL(r *rand.Rand) string {
+	u := "https://"
+	if r.Intn(2) == 0 {
+		u += "www."
+	}
+	u += Domain(r)
+
+	for i, n := 0, r.Intn(3); i < n; i++ {
+		u += "/" + getRandomString(r, internetWords)
+	}
+	return u
+}
+
+func IPv4(r *rand.Rand) string {
+	var ip [4]byte
+	for i := range ip {
+		ip[i] = byte(r.Intn(256))
+	}
+	// Avoid the unspecified 0.0.0.0/8 network so every address is usable.
+	if ip[0] == 0 {
+		ip[0] = 1
+	}
+	return net.IP(ip[:]).String()
+}
+
+// IPInNetwork generates an IPv4 or IPv6 address within the network given in
+// CIDR notation, such as 10.0.0.0/8.
+func IPInNetwork(r *rand.Rand, cidr string) (string, error) {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return "", err
+	}
+
+	ip := make(net.IP, len(network.IP))
+	for i := range ip {
+		ip[i] = network.IP[i] | (byte(r.Intn(256)) &^ network.Mask[i])
+	}
+	return ip.String(), nil
+}
+
+func IPv6(r *rand.Rand) string {
+	ip := make(net.IP, net.IPv6len)
+	// Use the 2000::/3 global unicast range.
+	ip[0] = 0x20 | byte(r.Intn(0x20))
+	for i := 1; i < len(ip); i++ {
+		ip[i] = byte(r.Intn(256))
+	}
+	return ip.String()
+}
+
+func MacAddress(r *rand.Rand) string {
+	mac := make(net.HardwareAddr, 6)
+	for i := range mac {
+		mac[i] = byte(r.Intn(256))
+	}
+	// Clear the multicast bit so the address identifies a single interface.
+	mac[0] &^= 0x01
+	return mac.String()
+}
+
+func UserAgent(r *rand.Rand) string {
+	platform := getRandomString(r, userAgentPlatforms)
+	major := 90 + r.Intn(40)
+
+	switch r.Intn(3) {
+	case 0:
+		return fmt.Sprintf("Mozilla/5.0 (%s) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/%d.0.%d.%d Safari/537.36", platform, major, 4000+r.Intn(2000), r.Intn(200))
+	case 1:
+		return fmt.Sprintf("Mozilla/5.0 (%s; rv:%d.0) Gecko/20100101 Firefox/%d.0", platform, major, major)
+	default:
+		return fmt.Sprintf("Mozilla/5.0 (%s) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/%d.%d Safari/605.1.15", platform, 13+r.Intn(5), r.Intn(7))
+	}
+}
+
+func Slug(r *rand.Rand) string {
+	words := make([]string, 2+r.Intn(3))
+	for i := range words {
+		words[i] = getRandomString(r, internetWords)
+	}
+	return strings.Join(words, "-")
+}
+
+func Password(r *rand.Rand) string {
+	return PasswordOfLength(r, 12+r.Intn(9))
+}
+
+// PasswordOfLength generates a password of the given length containing at
+// least one lowercase letter, uppercase letter, digit and symbol, provided the
+// length allows it.
+func PasswordOfLength(r *rand.Rand, n int) string {
+	classes := []string{
+		"abcdefghijklmnopqrstuvwxyz",
+		"ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+		"0123456789",
+		"!@#$%^&*-_=+?",
+	}
+
+	b := make([]byte, n)
+	for i := range b {
+		var class string
+		if i < len(classes) {
+			class = classes[i]
+		} else {
+			class = classes[r.Intn(len(classes))]
+		}
+		b[i] = class[r.Intn(len(class))]
+	}
+
+	r.Shuffle(len(b), func(i, j int) { b[i], b[j] = b[j], b[i] })
+	return string(b)
+}
+
+// slugify lowercases a word, removes accents from Latin letters, and removes
+// the characters that cannot appear in usernames and email addresses.
+func slugify(s string) string {
+	var sb strings.Builder
+	for _, c := range unaccented.Replace(strings.ToLower(s)) {
+		if ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') {
+			sb.WriteRune(c)
+		}
+	}
+	if sb.Len() == 0 {
+		return "user"
+	}
+	return sb.String()
+}
+
+var unaccented = strings.NewReplacer(
+	"à", "a", "á", "a", "â", "a", "ã", "a", "ä", "a", "å", "a",
+	"ç", "c",
+	"è", "e", "é", "e", "ê", "e", "ë", "e",
+	"ì", "i", "í", "i", "î", "i", "ï", "i",
+	"ñ", "n",
+	"ò", "o", "ó", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ù", "u", "ú", "u", "û", "u", "ü", "u",
+	"ß", "ss",
+)
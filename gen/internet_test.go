@@ -0,0 +1,130 @@
+package gen
+
+import (
+	"math/rand"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestInternet(t *testing.T) {
+	tests := []struct {
+		name  string
+		gen   func(r *rand.Rand) string
+		valid func(s string) bool
+	}{
+		{
+			name: "Email",
+			gen:  Email,
+			valid: func(s string) bool {
+				a, err := mail.ParseAddress(s)
+				return err == nil && a.Address == s
+			},
+		},
+		{
+			name:  "Username",
+			gen:   Username,
+			valid: regexp.MustCompile(`^[a-z0-9_]+$`).MatchString,
+		},
+		{
+			name:  "Domain",
+			gen:   Domain,
+			valid: regexp.MustCompile(`^[a-z]+(-[a-z]+)?\.[a-z]+$`).MatchString,
+		},
+		{
+			name: "URL",
+			gen:  URL,
+			valid: func(s string) bool {
+				u, err := url.Parse(s)
+				return err == nil && u.Scheme == "https" && u.Host != ""
+			},
+		},
+		{
+			name: "IPv4",
+			gen:  IPv4,
+			valid: func(s string) bool {
+				ip := net.ParseIP(s)
+				return ip != nil && ip.To4() != nil && ip[12] != 0
+			},
+		},
+		{
+			name: "IPv6",
+			gen:  IPv6,
+			valid: func(s string) bool {
+				ip := net.ParseIP(s)
+				return ip != nil && ip.To4() == nil && ip.IsGlobalUnicast()
+			},
+		},
+		{
+			name: "MacAddress",
+			gen:  MacAddress,
+			valid: func(s string) bool {
+				mac, err := net.ParseMAC(s)
+				return err == nil && len(mac) == 6 && mac[0]&1 == 0
+			},
+		},
+		{
+			name:  "UserAgent",
+			gen:   UserAgent,
+			valid: func(s string) bool { return strings.HasPrefix(s, "Mozilla/5.0 (") },
+		},
+		{
+			name:  "Slug",
+			gen:   Slug,
+			valid: regexp.MustCompile(`^[a-z]+(-[a-z]+)+$`).MatchString,
+		},
+		{
+			name:  "Password",
+			gen:   Password,
+			valid: func(s string) bool { return len(s) >= 12 && len(s) <= 20 },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(1))
+			for i := 0; i < 100; i++ {
+				if s := tt.gen(r); !tt.valid(s) {
+					t.Fatalf("%s() = %q is invalid", tt.name, s)
+				}
+			}
+		})
+	}
+}
+
+func TestPasswordOfLength(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, n := range []int{4, 8, 32} {
+		p := PasswordOfLength(r, n)
+		if len(p) != n {
+			t.Errorf("PasswordOfLength(%d) = %q", n, p)
+		}
+		for _, class := range []string{"abcdefghijklmnopqrstuvwxyz", "ABCDEFGHIJKLMNOPQRSTUVWXYZ", "0123456789", "!@#$%^&*-_=+?"} {
+			if !strings.ContainsAny(p, class) {
+				t.Errorf("PasswordOfLength(%d) = %q is missing one of %q", n, p, class)
+			}
+		}
+	}
+}
+
+func TestIPInNetwork(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, cidr := range []string{"10.0.0.0/8", "192.168.1.0/24", "fd00::/8"} {
+		_, network, _ := net.ParseCIDR(cidr)
+		for i := 0; i < 50; i++ {
+			s, err := IPInNetwork(r, cidr)
+			if err != nil {
+				t.Fatalf("IPInNetwork(%s) error = %v", cidr, err)
+			}
+			if !network.Contains(net.ParseIP(s)) {
+				t.Fatalf("IPInNetwork(%s) = %s", cidr, s)
+			}
+		}
+	}
+
+	if _, err := IPInNetwork(r, "10.0.0.0"); err == nil {
+		t.Errorf("IPInNetwork() error = nil for an invalid network")
+	}
+}
@@ -0,0 +1,251 @@
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Locale bundles the names, address formats, phone number formats and postal
+// code patterns of a country and language. The package level functions use
+// the en_US locale.
+type Locale struct {
+	// Code identifies the locale, such as en_US.
+	Code string
+	// Country and CountryCode name the country whose addresses the locale
+	// generates.
+	Country     string
+	CountryCode string
+
+	femaleNames []string
+	maleNames   []string
+	lastNames   []string
+	// familyNameFirst writes full names with the family name first.
+	familyNameFirst bool
+	// femalePrefixes and malePrefixes are the honorifics written before a
+	// name. They are empty where honorifics are not written before names.
+	femalePrefixes []string
+	malePrefixes   []string
+	// latin spells names written in another script in the Latin alphabet.
+	latin map[string]string
+	// phoneFormats are patterns in which # is replaced by any digit and % by
+	// any digit but zero.
+	phoneFormats []string
+	places       []place
+	street       func(r *rand.Rand) string
+	// postalCode is a pattern in the same form as phoneFormats. The place's
+	// postal code prefix replaces the start of the pattern.
+	postalCode string
+}
+
+// DefaultLocale is the locale used by the package level functions.
+var DefaultLocale = enUS
+
+var locales = map[string]*Locale{}
+
+func init() {
+	for _, l := range []*Locale{enUS, deDE, frFR, jaJP, ptBR} {
+		locales[l.Code] = l
+	}
+}
+
+// LookupLocale returns the locale with the given code, such as de_DE. The
+// language and country may also be separated by a hyphen, as in de-DE.
+func LookupLocale(code string) (*Locale, error) {
+	if l, ok := locales[strings.Replace(code, "-", "_", 1)]; ok {
+		return l, nil
+	}
+	return nil, fmt.Errorf("unknown locale %q, expected one of %s", code, strings.Join(Locales(), ", "))
+}
+
+// MustLookupLocale is like LookupLocale but panics if the locale is unknown.
+func MustLookupLocale(code string) *Locale {
+	l, err := LookupLocale(code)
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
+
+// Locales lists the codes of the available locales.
+func Locales() []string {
+	codes := make([]string, 0, len(locales))
+	for c := range locales {
+		codes = append(codes, c)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+func (l *Locale) Name(r *rand.Rand) string {
+	first, last := l.FirstName(r), l.LastName(r)
+	if l.familyNameFirst {
+		return last + " " + first
+	}
+	return first + " " + last
+}
+
+func (l *Locale) FirstName(r *rand.Rand) string {
+	i := r.Intn(len(l.femaleNames) + len(l.maleNames))
+	if i < len(l.femaleNames) {
+		return l.femaleNames[i]
+	}
+	return l.maleNames[i-len(l.femaleNames)]
+}
+
+func (l *Locale) LastName(r *rand.Rand) string {
+	return getRandomString(r, l.lastNames)
+}
+
+func (l *Locale) PhoneNumber(r *rand.Rand) string {
+	format := l.phoneFormats[0]
+	if len(l.phoneFormats) > 1 {
+		format = getRandomString(r, l.phoneFormats)
+	}
+	return fillPattern(r, format)
+}
+
+func (l *Locale) Address(r *rand.Rand) PostalAddress {
+	p := l.places[r.Intn(len(l.places))]
+
+	return PostalAddress{
+		Street:      l.StreetAddress(r),
+		City:        p.city,
+		State:       p.state,
+		StateCode:   p.stateCode,
+		PostalCode:  l.postalCodeIn(r, p),
+		Country:     l.Country,
+		CountryCode: l.CountryCode,
+		Latitude:    round(p.lat+(r.Float64()-0.5)*0.2, 6),
+		Longitude:   round(p.lng+(r.Float64()-0.5)*0.2, 6),
+	}
+}
+
+func (l *Locale) StreetAddress(r *rand.Rand) string {
+	return l.street(r)
+}
+
+func (l *Locale) City(r *rand.Rand) string {
+	return l.places[r.Intn(len(l.places))].city
+}
+
+func (l *Locale) State(r *rand.Rand) string {
+	return l.places[r.Intn(len(l.places))].state
+}
+
+func (l *Locale) StateCode(r *rand.Rand) string {
+	return l.places[r.Intn(len(l.places))].stateCode
+}
+
+func (l *Locale) PostalCode(r *rand.Rand) string {
+	return l.postalCodeIn(r, l.places[r.Intn(len(l.places))])
+}
+
+// postalCodeIn generates a postal code belonging to the place.
+func (l *Locale) postalCodeIn(r *rand.Rand, p place) string {
+	return p.zip + fillPattern(r, l.postalCode[len(p.zip):])
+}
+
+// fillPattern replaces each # in the pattern with a digit, and each % with a
+// digit other than zero.
+func fillPattern(r *rand.Rand, pattern string) string {
+	const digits = "1234567890"
+
+	b := []byte(pattern)
+	for i, c := range b {
+		switch c {
+		case '#':
+			b[i] = digits[r.Intn(len(digits))]
+		case '%':
+			b[i] = digits[r.Intn(len(digits)-1)]
+		}
+	}
+	return string(b)
+}
+
+var enUS = &Locale{
+	Code:           "en_US",
+	Country:        "United States",
+	CountryCode:    "US",
+	femaleNames:    femaleNames,
+	maleNames:      maleNames,
+	lastNames:      lastNames,
+	femalePrefixes: []string{"Ms.", "Mrs.", "Dr."},
+	malePrefixes:   []string{"Mr.", "Dr."},
+	phoneFormats:   []string{"###-###-####"},
+	places:         places,
+	street: func(r *rand.Rand) string {
+		return strconv.Itoa(1+r.Intn(9999)) + " " + getRandomString(r, streetNames) + " " + getRandomString(r, streetSuffixes)
+	},
+	postalCode: "#####",
+}
+
+var deDE = &Locale{
+	Code:           "de_DE",
+	Country:        "Deutschland",
+	CountryCode:    "DE",
+	femaleNames:    deFemaleNames,
+	maleNames:      deMaleNames,
+	lastNames:      deLastNames,
+	femalePrefixes: []string{"Frau", "Dr."},
+	malePrefixes:   []string{"Herr", "Dr."},
+	phoneFormats:   []string{"0%## #######", "0%### ######", "+49 %## #######"},
+	places:         dePlaces,
+	street: func(r *rand.Rand) string {
+		return getRandomString(r, deStreetNames) + " " + strconv.Itoa(1+r.Intn(150))
+	},
+	postalCode: "#####",
+}
+
+var frFR = &Locale{
+	Code:           "fr_FR",
+	Country:        "France",
+	CountryCode:    "FR",
+	femaleNames:    frFemaleNames,
+	maleNames:      frMaleNames,
+	lastNames:      frLastNames,
+	femalePrefixes: []string{"Mme", "Mlle"},
+	malePrefixes:   []string{"M."},
+	phoneFormats:   []string{"0% ## ## ## ##", "+33 % ## ## ## ##"},
+	places:         frPlaces,
+	street: func(r *rand.Rand) string {
+		return strconv.Itoa(1+r.Intn(200)) + " " + getRandomString(r, frStreetNames)
+	},
+	postalCode: "#####",
+}
+
+var jaJP = &Locale{
+	Code:            "ja_JP",
+	Country:         "日本",
+	CountryCode:     "JP",
+	femaleNames:     jaFemaleNames,
+	maleNames:       jaMaleNames,
+	lastNames:       jaLastNames,
+	familyNameFirst: true,
+	latin:           jaRomaji,
+	phoneFormats:    []string{"0%-####-####", "0%#-###-####", "090-####-####", "080-####-####"},
+	places:          jaPlaces,
+	street: func(r *rand.Rand) string {
+		return getRandomString(r, jaStreetNames) + strconv.Itoa(1+r.Intn(9)) + "丁目" + strconv.Itoa(1+r.Intn(30)) + "-" + strconv.Itoa(1+r.Intn(20))
+	},
+	postalCode: "###-####",
+}
+
+var ptBR = &Locale{
+	Code:           "pt_BR",
+	Country:        "Brasil",
+	CountryCode:    "BR",
+	femaleNames:    ptFemaleNames,
+	maleNames:      ptMaleNames,
+	lastNames:      ptLastNames,
+	femalePrefixes: []string{"Sra.", "Dra."},
+	malePrefixes:   []string{"Sr.", "Dr."},
+	phoneFormats:   []string{"(%#) 9####-####", "(%#) %###-####"},
+	places:         ptPlaces,
+	street: func(r *rand.Rand) string {
+		return getRandomString(r, ptStreetNames) + ", " + strconv.Itoa(1+r.Intn(3000))
+	},
+	postalCode: "#####-###",
+}
@@ -0,0 +1,110 @@
+package gen
+
+import (
+	"math/rand"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestLocales(t *testing.T) {
+	tests := []struct {
+		code   string
+		phone  *regexp.Regexp
+		postal *regexp.Regexp
+		street *regexp.Regexp
+	}{
+		{
+			code:   "en_US",
+			phone:  regexp.MustCompile(`^\d{3}-\d{3}-\d{4}$`),
+			postal: regexp.MustCompile(`^\d{5}$`),
+			street: regexp.MustCompile(`^\d+ \w+ \w+$`),
+		},
+		{
+			code:   "de_DE",
+			phone:  regexp.MustCompile(`^(0[1-9]\d{2,3} \d{6,7}|\+49 [1-9]\d{2} \d{7})$`),
+			postal: regexp.MustCompile(`^\d{5}$`),
+			street: regexp.MustCompile(`^[\p{L} ]+ \d+$`),
+		},
+		{
+			code:   "fr_FR",
+			phone:  regexp.MustCompile(`^(0[1-9]|\+33 [1-9])( \d{2}){4}$`),
+			postal: regexp.MustCompile(`^\d{5}$`),
+			street: regexp.MustCompile(`^\d+ [\p{L} '-]+$`),
+		},
+		{
+			code:   "ja_JP",
+			phone:  regexp.MustCompile(`^0\d{1,2}-\d{3,4}-\d{4}$`),
+			postal: regexp.MustCompile(`^\d{3}-\d{4}$`),
+			street: regexp.MustCompile(`^[\p{Han}\p{Hiragana}]+\d丁目\d+-\d+$`),
+		},
+		{
+			code:   "pt_BR",
+			phone:  regexp.MustCompile(`^\([1-9]\d\) [1-9]\d{3,4}-\d{4}$`),
+			postal: regexp.MustCompile(`^\d{5}-\d{3}$`),
+			street: regexp.MustCompile(`^[\p{L} ]+, \d+$`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			l, err := LookupLocale(tt.code)
+			if err != nil {
+				t.Fatalf("LookupLocale() error = %v", err)
+			}
+
+			r := rand.New(rand.NewSource(1))
+			for i := 0; i < 200; i++ {
+				if s := l.PhoneNumber(r); !tt.phone.MatchString(s) {
+					t.Fatalf("PhoneNumber() = %s", s)
+				}
+
+				a := l.Address(r)
+				var p *place
+				for j := range l.places {
+					if l.places[j].city == a.City {
+						p = &l.places[j]
+					}
+				}
+				if p == nil || a.State != p.state || !strings.HasPrefix(a.PostalCode, p.zip) || a.CountryCode != l.CountryCode {
+					t.Fatalf("Address() = %+v is not consistent", a)
+				}
+				if !tt.postal.MatchString(a.PostalCode) || !tt.street.MatchString(a.Street) {
+					t.Fatalf("Address() = %+v is badly formatted", a)
+				}
+			}
+		})
+	}
+}
+
+func TestLocale_Name(t *testing.T) {
+	l, err := LookupLocale("ja-JP")
+	if err != nil {
+		t.Fatalf("LookupLocale() error = %v", err)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		parts := strings.Split(l.Name(r), " ")
+		if len(parts) != 2 || !contains(l.lastNames, parts[0]) || !contains(append(l.femaleNames, l.maleNames...), parts[1]) {
+			t.Fatalf("Name() = %q, want the family name first", strings.Join(parts, " "))
+		}
+	}
+}
+
+func TestLookupLocale(t *testing.T) {
+	if _, err := LookupLocale("xx_XX"); err == nil || !strings.Contains(err.Error(), "de_DE") {
+		t.Errorf("LookupLocale() error = %v, want the available locales listed", err)
+	}
+	if l, err := LookupLocale("en_US"); err != nil || l != DefaultLocale {
+		t.Errorf("LookupLocale(en_US) = %v, %v, want the default locale", l, err)
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
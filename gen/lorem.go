@@ -0,0 +1,139 @@
+package gen
+
+import (
+	"math/rand"
+	"strings"
+)
+
+func Word(r *rand.Rand) string {
+	return getRandomString(r, loremWords)
+}
+
+func Words(r *rand.Rand) string {
+	return WordsOfLength(r, 2+r.Intn(5))
+}
+
+// WordsOfLength generates n lowercase words separated by spaces.
+func WordsOfLength(r *rand.Rand, n int) string {
+	return strings.Join(words(r, n), " ")
+}
+
+func Sentence(r *rand.Rand) string {
+	return SentenceOfLength(r, 4+r.Intn(9))
+}
+
+// SentenceOfLength generates a sentence of n words. The first word is
+// capitalized, the sentence ends with a period, and sentences of eight or more
+// words may be broken up by a comma.
+func SentenceOfLength(r *rand.Rand, n int) string {
+	if n <= 0 {
+		return ""
+	}
+
+	w := words(r, n)
+	if n >= 8 && r.Intn(2) == 1 {
+		// Keep the comma away from either end of the sentence.
+		w[2+r.Intn(n-4)] += ","
+	}
+	w[0] = capitalize(w[0])
+	return strings.Join(w, " ") + "."
+}
+
+func Paragraph(r *rand.Rand) string {
+	return ParagraphOfLength(r, 3+r.Intn(4))
+}
+
+// ParagraphOfLength generates a paragraph of n sentences.
+func ParagraphOfLength(r *rand.Rand, n int) string {
+	var s []string
+	for i := 0; i < n; i++ {
+		s = append(s, Sentence(r))
+	}
+	return strings.Join(s, " ")
+}
+
+func Title(r *rand.Rand) string {
+	return TitleOfLength(r, 2+r.Intn(4))
+}
+
+// TitleOfLength generates a title of n words. Words are capitalized, apart
+// from short words after the first, as in English titles.
+func TitleOfLength(r *rand.Rand, n int) string {
+	w := words(r, n)
+	for i := range w {
+		if i == 0 || len(w[i]) > 3 {
+			w[i] = capitalize(w[i])
+		}
+	}
+	return strings.Join(w, " ")
+}
+
+func Markdown(r *rand.Rand) string {
+	return MarkdownOfLength(r, 2+r.Intn(3))
+}
+
+// MarkdownOfLength generates a markdown document with a title followed by n
+// sections. Each section has a heading and a paragraph, and may contain a
+// bulleted list, emphasized words or inline code.
+func MarkdownOfLength(r *rand.Rand, n int) string {
+	var sb strings.Builder
+	sb.WriteString("# ")
+	sb.WriteString(Title(r))
+	sb.WriteString("\n")
+
+	for i := 0; i < n; i++ {
+		sb.WriteString("\n## ")
+		sb.WriteString(Title(r))
+		sb.WriteString("\n\n")
+
+		p := strings.Split(Paragraph(r), " ")
+		switch r.Intn(3) {
+		case 1:
+			j := r.Intn(len(p))
+			p[j] = wrapWord(p[j], "**")
+		case 2:
+			j := r.Intn(len(p))
+			p[j] = wrapWord(p[j], "`")
+		}
+		sb.WriteString(strings.Join(p, " "))
+		sb.WriteString("\n")
+
+		if r.Intn(2) == 1 {
+			sb.WriteString("\n")
+			for j, m := 0, 2+r.Intn(3); j < m; j++ {
+				sb.WriteString("- ")
+				sb.WriteString(WordsOfLength(r, 2+r.Intn(4)))
+				sb.WriteString("\n")
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+// wrapWord surrounds a word with a markdown delimiter, leaving any trailing
+// punctuation outside of it.
+func wrapWord(w, delim string) string {
+	t := strings.TrimRight(w, ".,")
+	return delim + t + delim + w[len(t):]
+}
+
+// words picks n words from the lorem ipsum vocabulary.
+func words(r *rand.Rand, n int) []string {
+	if n < 0 {
+		n = 0
+	}
+	w := make([]string, n)
+	for i := range w {
+		w[i] = Word(r)
+	}
+	return w
+}
+
+// capitalize upper cases the first letter of an ASCII word.
+func capitalize(s string) string {
+	if s == "" || s[0] < 'a' || s[0] > 'z' {
+		return s
+	}
+	return string(s[0]-'a'+'A') + s[1:]
+}
@@ -0,0 +1,92 @@
+package gen
+
+import (
+	"math/rand"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestWordsOfLength(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for n := 0; n < 10; n++ {
+		s := WordsOfLength(r, n)
+		if got := len(strings.Fields(s)); got != n {
+			t.Errorf("WordsOfLength(%d) = %q, has %d words", n, s, got)
+		}
+	}
+}
+
+func TestSentenceOfLength(t *testing.T) {
+	re := regexp.MustCompile(`^[A-Z][a-z]*(,? [a-z]+)*\.$`)
+
+	r := rand.New(rand.NewSource(1))
+	for n := 1; n < 20; n++ {
+		s := SentenceOfLength(r, n)
+		if !re.MatchString(s) {
+			t.Errorf("SentenceOfLength(%d) = %q", n, s)
+		}
+		if got := len(strings.Fields(s)); got != n {
+			t.Errorf("SentenceOfLength(%d) = %q, has %d words", n, s, got)
+		}
+	}
+
+	if s := SentenceOfLength(r, 0); s != "" {
+		t.Errorf("SentenceOfLength(0) = %q, want empty string", s)
+	}
+}
+
+func TestParagraphOfLength(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for n := 0; n < 10; n++ {
+		s := ParagraphOfLength(r, n)
+		if got := strings.Count(s, "."); got != n {
+			t.Errorf("ParagraphOfLength(%d) = %q, has %d sentences", n, s, got)
+		}
+	}
+}
+
+func TestTitleOfLength(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		s := TitleOfLength(r, 5)
+		w := strings.Fields(s)
+		if len(w) != 5 {
+			t.Fatalf("TitleOfLength(5) = %q", s)
+		}
+		for j, word := range w {
+			if upper := capitalize(word) == word; upper != (j == 0 || len(word) > 3) {
+				t.Fatalf("TitleOfLength(5) = %q", s)
+			}
+		}
+	}
+}
+
+func TestMarkdownOfLength(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for n := 0; n < 5; n++ {
+		s := MarkdownOfLength(r, n)
+		if !strings.HasPrefix(s, "# ") {
+			t.Errorf("MarkdownOfLength(%d) = %q, want a title", n, s)
+		}
+		if got := strings.Count(s, "\n## "); got != n {
+			t.Errorf("MarkdownOfLength(%d) = %q, has %d sections", n, s, got)
+		}
+	}
+}
+
+func TestWrapWord(t *testing.T) {
+	tests := []struct {
+		word string
+		want string
+	}{
+		{word: "lorem", want: "**lorem**"},
+		{word: "lorem,", want: "**lorem**,"},
+		{word: "Lorem.", want: "**Lorem**."},
+	}
+	for _, tt := range tests {
+		if got := wrapWord(tt.word, "**"); got != tt.want {
+			t.Errorf("wrapWord(%q) = %q, want %q", tt.word, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package gen
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Float generates a number between 0 and 1 exclusive.
+func Float(r *rand.Rand) float64 {
+	return FloatBetween(r, 0, 1)
+}
+
+// FloatBetween generates a number between min and max exclusive, so that the
+// result satisfies both inclusive and exclusive bounds. If no number lies
+// between them, min is returned.
+func FloatBetween(r *rand.Rand, min, max float64) float64 {
+	if max <= min || math.Nextafter(min, max) >= max {
+		return min
+	}
+
+	for {
+		// Rounding, or a draw of 0, can land on either bound.
+		if f := min + r.Float64()*(max-min); f > min && f < max {
+			return f
+		}
+	}
+}
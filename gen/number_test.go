@@ -0,0 +1,30 @@
+package gen
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestFloatBetween(t *testing.T) {
+	tests := []struct {
+		min, max float64
+	}{
+		{min: 0.1, max: 0.9},
+		{min: -5, max: -4.5},
+		{min: 1, max: 1.0000000000000004},
+	}
+	for _, tt := range tests {
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 1000; i++ {
+			if f := FloatBetween(r, tt.min, tt.max); f <= tt.min || f >= tt.max {
+				t.Fatalf("FloatBetween(%v, %v) = %v", tt.min, tt.max, f)
+			}
+		}
+	}
+
+	for _, max := range []float64{2.5, 2.5000000000000004} {
+		if f := FloatBetween(rand.New(rand.NewSource(1)), 2.5, max); f != 2.5 {
+			t.Errorf("FloatBetween(2.5, %v) = %v, want 2.5", max, f)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Profile describes a person whose details agree with each other. The first
+// name and prefix match the gender, the email address is derived from the
+// name, and the age is the number of whole years since the birthdate.
+type Profile struct {
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+	FullName  string `json:"fullName"`
+	Gender    string `json:"gender"`
+	Prefix    string `json:"prefix,omitempty"`
+	Email     string `json:"email"`
+	Birthdate string `json:"birthdate"`
+	Age       int    `json:"age"`
+	Avatar    string `json:"avatar,omitempty"`
+}
+
+const (
+	minAge = 18
+	maxAge = 80
+)
+
+func Person(r *rand.Rand) Profile {
+	return DefaultLocale.Person(r)
+}
+
+// Person generates the profile of an adult in the locale. Prefixes are left
+// empty in locales that do not write honorifics before names, and about half
+// of the profiles have an avatar.
+func (l *Locale) Person(r *rand.Rand) Profile {
+	p := Profile{Gender: "female"}
+
+	names, prefixes := l.femaleNames, l.femalePrefixes
+	if r.Intn(2) == 1 {
+		p.Gender = "male"
+		names, prefixes = l.maleNames, l.malePrefixes
+	}
+
+	p.FirstName, p.LastName = getRandomString(r, names), l.LastName(r)
+	p.FullName = p.FirstName + " " + p.LastName
+	if l.familyNameFirst {
+		p.FullName = p.LastName + " " + p.FirstName
+	}
+	if len(prefixes) > 0 {
+		p.Prefix = getRandomString(r, prefixes)
+	}
+
+	p.Email = localPart(r, l.latinName(p.FirstName), l.latinName(p.LastName)) + "@" + getRandomString(r, freeEmailDomains)
+
+	today := ReferenceTime.UTC().Truncate(24 * time.Hour)
+	birth := birthdate(r, today)
+	p.Birthdate = birth.Format("2006-01-02")
+	p.Age = age(birth, today)
+
+	if r.Intn(2) == 1 {
+		p.Avatar = fmt.Sprintf("https://avatars.example.com/%s/%016x.png", p.Gender, r.Uint64())
+	}
+	return p
+}
+
+// latinName spells a name in the Latin alphabet where the locale knows how.
+func (l *Locale) latinName(name string) string {
+	if s, ok := l.latin[name]; ok {
+		return s
+	}
+	return name
+}
+
+// birthdate chooses the birthdate of someone between minAge and maxAge years
+// old on the given day.
+func birthdate(r *rand.Rand, today time.Time) time.Time {
+	earliest := today.AddDate(-maxAge-1, 0, 1)
+	latest := today.AddDate(-minAge, 0, 0)
+	if latest.Day() != today.Day() {
+		// February 29th moved into March of a common year.
+		latest = latest.AddDate(0, 0, -1)
+	}
+
+	days := int(latest.Sub(earliest).Hours() / 24)
+	return earliest.AddDate(0, 0, r.Intn(days+1))
+}
+
+// age counts the birthdays that have passed by the given day.
+func age(birth, today time.Time) int {
+	n := today.Year() - birth.Year()
+	if today.Month() < birth.Month() || (today.Month() == birth.Month() && today.Day() < birth.Day()) {
+		n--
+	}
+	return n
+}
@@ -0,0 +1,77 @@
+package gen
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPerson(t *testing.T) {
+	for _, code := range Locales() {
+		t.Run(code, func(t *testing.T) {
+			l := MustLookupLocale(code)
+
+			r := rand.New(rand.NewSource(1))
+			avatars := 0
+			for i := 0; i < 200; i++ {
+				p := l.Person(r)
+
+				names, prefixes := l.femaleNames, l.femalePrefixes
+				if p.Gender == "male" {
+					names, prefixes = l.maleNames, l.malePrefixes
+				} else if p.Gender != "female" {
+					t.Fatalf("Person() gender = %q", p.Gender)
+				}
+				if !contains(names, p.FirstName) || !contains(l.lastNames, p.LastName) {
+					t.Fatalf("Person() = %+v has a name not matching the gender", p)
+				}
+				if (len(prefixes) > 0 || p.Prefix != "") && !contains(prefixes, p.Prefix) {
+					t.Fatalf("Person() = %+v has a prefix not matching the gender", p)
+				}
+				if !strings.Contains(p.FullName, p.FirstName) || !strings.Contains(p.FullName, p.LastName) {
+					t.Fatalf("Person() = %+v has an inconsistent full name", p)
+				}
+
+				local := p.Email[:strings.Index(p.Email, "@")]
+				if !strings.HasPrefix(local, slugify(l.latinName(p.FirstName))[:1]) {
+					t.Fatalf("Person() = %+v has an email not derived from the name", p)
+				}
+
+				birth, err := time.Parse("2006-01-02", p.Birthdate)
+				if err != nil {
+					t.Fatalf("Person() birthdate = %q", p.Birthdate)
+				}
+				if p.Age < minAge || p.Age > maxAge || birth.AddDate(p.Age, 0, 0).After(ReferenceTime) || !birth.AddDate(p.Age+1, 0, 0).After(ReferenceTime) {
+					t.Fatalf("Person() = %+v has an age not matching the birthdate", p)
+				}
+
+				if p.Avatar != "" {
+					avatars++
+				}
+			}
+			if avatars == 0 || avatars == 200 {
+				t.Errorf("Person() generated %d avatars in 200 profiles", avatars)
+			}
+		})
+	}
+}
+
+func TestAge(t *testing.T) {
+	tests := []struct {
+		birth, today string
+		want         int
+	}{
+		{birth: "2000-06-15", today: "2020-06-14", want: 19},
+		{birth: "2000-06-15", today: "2020-06-15", want: 20},
+		{birth: "2000-02-29", today: "2021-02-28", want: 20},
+		{birth: "2000-02-29", today: "2021-03-01", want: 21},
+	}
+	for _, tt := range tests {
+		birth, _ := time.Parse("2006-01-02", tt.birth)
+		today, _ := time.Parse("2006-01-02", tt.today)
+		if got := age(birth, today); got != tt.want {
+			t.Errorf("age(%s, %s) = %d, want %d", tt.birth, tt.today, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReferenceTime stands in for the present wherever a default window of time
+// would otherwise end at the current time: Date, DateTime, UnixTime and
+// UnixMillis generate times between 1970 and ReferenceTime, and Person counts
+// ages on its day. A fixed time keeps seeded generations reproducible. It can
+// be changed before generating to move these windows.
+var ReferenceTime = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// TimeBetween generates a time between min and max inclusive, with a
+// resolution of one second, in UTC. If no whole second lies between them, min
+// rounded up to the second is returned.
+func TimeBetween(r *rand.Rand, min, max time.Time) time.Time {
+	lo, hi := min.Add(time.Second-1).Unix(), max.Unix()
+	if hi <= lo {
+		return time.Unix(lo, 0).UTC()
+	}
+	return time.Unix(lo+r.Int63n(hi-lo+1), 0).UTC()
+}
+
+// DurationBetween generates a duration between min and max inclusive. The
+// resolution is the largest of a second, millisecond, microsecond or
+// nanosecond that divides both bounds, so whole second bounds produce whole
+// second durations.
+func DurationBetween(r *rand.Rand, min, max time.Duration) time.Duration {
+	unit := time.Second
+	for min%unit != 0 || max%unit != 0 {
+		unit /= 1000
+	}
+
+	lo, hi := min/unit, max/unit
+	if hi <= lo {
+		return min
+	}
+	return (lo + time.Duration(r.Int63n(int64(hi-lo+1)))) * unit
+}
+
+func Date(r *rand.Rand) string {
+	return TimeBetween(r, time.Unix(0, 0), ReferenceTime).Format("2006-01-02")
+}
+
+func TimeOfDay(r *rand.Rand) string {
+	return TimeBetween(r, time.Unix(0, 0), time.Unix(86399, 0)).Format("15:04:05")
+}
+
+func DateTime(r *rand.Rand) string {
+	return TimeBetween(r, time.Unix(0, 0), ReferenceTime).Format(time.RFC3339)
+}
+
+func Duration(r *rand.Rand) time.Duration {
+	return DurationBetween(r, time.Second, 24*time.Hour)
+}
+
+func UnixTime(r *rand.Rand) int64 {
+	return TimeBetween(r, time.Unix(0, 0), ReferenceTime).Unix()
+}
+
+func UnixMillis(r *rand.Rand) int64 {
+	return r.Int63n(ReferenceTime.UnixNano()/int64(time.Millisecond) + 1)
+}
+
+// Past generates a time within the last year.
+func Past(r *rand.Rand) time.Time {
+	now := time.Now()
+	return TimeBetween(r, now.AddDate(-1, 0, 0), now)
+}
+
+// Future generates a time within the next year.
+func Future(r *rand.Rand) time.Time {
+	now := time.Now()
+	return TimeBetween(r, now, now.AddDate(1, 0, 0))
+}
+
+// Recent generates a time within the last day.
+func Recent(r *rand.Rand) time.Time {
+	now := time.Now()
+	return TimeBetween(r, now.Add(-24*time.Hour), now)
+}
+
+// strftimeLayouts maps the strftime directives that have a Go equivalent to
+// the Go layout producing them.
+var strftimeLayouts = map[byte]string{
+	'a': "Mon",
+	'A': "Monday",
+	'b': "Jan",
+	'B': "January",
+	'd': "02",
+	'D': "01/02/06",
+	'e': "_2",
+	'F': "2006-01-02",
+	'H': "15",
+	'I': "03",
+	'm': "01",
+	'M': "04",
+	'p': "PM",
+	'S': "05",
+	'T': "15:04:05",
+	'y': "06",
+	'Y': "2006",
+	'z': "-0700",
+	'Z': "MST",
+}
+
+// Strftime formats a time using the directives of the C strftime function,
+// such as %Y-%m-%d. Along with the directives with a Go equivalent, %j gives
+// the day of the year, %s the Unix time, %f the microseconds and %% a percent
+// sign. An error is returned for any other directive.
+func Strftime(t time.Time, format string) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			sb.WriteByte(format[i])
+			continue
+		}
+
+		if i++; i == len(format) {
+			return "", fmt.Errorf("incomplete strftime directive at the end of %q", format)
+		}
+
+		c := format[i]
+		if layout, ok := strftimeLayouts[c]; ok {
+			sb.WriteString(t.Format(layout))
+			continue
+		}
+
+		switch c {
+		case 'j':
+			fmt.Fprintf(&sb, "%03d", t.YearDay())
+		case 's':
+			sb.WriteString(strconv.FormatInt(t.Unix(), 10))
+		case 'f':
+			fmt.Fprintf(&sb, "%06d", t.Nanosecond()/1000)
+		case '%':
+			sb.WriteByte('%')
+		default:
+			return "", fmt.Errorf("unknown strftime directive %%%c", c)
+		}
+	}
+	return sb.String(), nil
+}
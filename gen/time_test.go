@@ -0,0 +1,125 @@
+package gen
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestTimeBetween(t *testing.T) {
+	min := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	max := min.Add(90 * time.Second)
+
+	r := rand.New(rand.NewSource(1))
+	seen := make(map[time.Time]bool)
+	for i := 0; i < 2000; i++ {
+		got := TimeBetween(r, min, max)
+		if got.Before(min) || got.After(max) || got.Nanosecond() != 0 || got.Location() != time.UTC {
+			t.Fatalf("TimeBetween() = %v", got)
+		}
+		seen[got] = true
+	}
+	if !seen[min] || !seen[max] {
+		t.Errorf("TimeBetween() never generated the bounds")
+	}
+
+	if got := TimeBetween(r, max, min); !got.Equal(max) {
+		t.Errorf("TimeBetween() with reversed bounds = %v, want %v", got, max)
+	}
+
+	// Bounds between whole seconds are rounded inwards.
+	min, max = min.Add(500*time.Millisecond), min.Add(2500*time.Millisecond)
+	for i := 0; i < 100; i++ {
+		if got := TimeBetween(r, min, max); got.Before(min) || got.After(max) {
+			t.Fatalf("TimeBetween(%v, %v) = %v", min, max, got)
+		}
+	}
+}
+
+func TestDurationBetween(t *testing.T) {
+	tests := []struct {
+		min, max time.Duration
+		unit     time.Duration
+	}{
+		{min: time.Second, max: time.Hour, unit: time.Second},
+		{min: 0, max: 1500 * time.Millisecond, unit: time.Millisecond},
+		{min: time.Nanosecond, max: time.Second, unit: time.Nanosecond},
+	}
+	for _, tt := range tests {
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 100; i++ {
+			got := DurationBetween(r, tt.min, tt.max)
+			if got < tt.min || got > tt.max || got%tt.unit != 0 {
+				t.Fatalf("DurationBetween(%v, %v) = %v", tt.min, tt.max, got)
+			}
+		}
+	}
+}
+
+func TestStrftime(t *testing.T) {
+	ts := time.Date(2021, 3, 7, 14, 5, 9, 123456789, time.UTC)
+
+	tests := []struct {
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{format: "%Y-%m-%d %H:%M:%S", want: "2021-03-07 14:05:09"},
+		{format: "%a %A %b %B %e", want: "Sun Sunday Mar March  7"},
+		{format: "%I %p %y %j", want: "02 PM 21 066"},
+		{format: "%F %T %z %Z", want: "2021-03-07 14:05:09 +0000 UTC"},
+		{format: "%s.%f 100%%", want: "1615125909.123456 100%"},
+		{format: "no directives", want: "no directives"},
+		{format: "%Q", wantErr: true},
+		{format: "%Y%", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := Strftime(ts, tt.format)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Strftime(%q) error = %v, wantErr %v", tt.format, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Strftime(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestDefaultWindows(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		date, err := time.Parse("2006-01-02", Date(r))
+		if err != nil || date.After(ReferenceTime) {
+			t.Fatalf("Date() = %v", date)
+		}
+		datetime, err := time.Parse(time.RFC3339, DateTime(r))
+		if err != nil || datetime.After(ReferenceTime) {
+			t.Fatalf("DateTime() = %v", datetime)
+		}
+		if u := UnixTime(r); u < 0 || u > ReferenceTime.Unix() {
+			t.Fatalf("UnixTime() = %d", u)
+		}
+		if ms := UnixMillis(r); ms < 0 || ms > ReferenceTime.UnixNano()/int64(time.Millisecond) {
+			t.Fatalf("UnixMillis() = %d", ms)
+		}
+		if ts := TimestampRand(r); ts.After(ReferenceTime) {
+			t.Fatalf("TimestampRand() = %v", ts)
+		}
+	}
+}
+
+func TestPast(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		now := time.Now()
+		if got := Past(r); got.After(now) || got.Before(now.AddDate(-1, 0, 0).Add(-time.Second)) {
+			t.Fatalf("Past() = %v", got)
+		}
+		if got := Recent(r); got.After(now) || got.Before(now.Add(-25*time.Hour)) {
+			t.Fatalf("Recent() = %v", got)
+		}
+		if got := Future(r); got.Before(now.Add(-time.Second)) || got.After(now.AddDate(1, 0, 1)) {
+			t.Fatalf("Future() = %v", got)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package sham
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestSchema_GenerateE(t *testing.T) {
+	errLookup := errors.New("lookup failed")
+	generators := map[string]Generator{
+		"ok":   RandGeneratorFunc(func(r *rand.Rand) interface{} { return "ok" }),
+		"fail": ErrGeneratorFunc(func(ctx context.Context, r *rand.Rand) (interface{}, error) { return nil, errLookup }),
+		"nil":  nil,
+	}
+
+	tests := []struct {
+		name     string
+		schema   string
+		want     interface{}
+		wantPath string
+		wantErr  error
+	}{
+		{
+			name:   "Generators that cannot fail are adapted",
+			schema: `[(3,3), ok]`,
+			want:   []interface{}{"ok", "ok", "ok"},
+		},
+		{
+			name:     "Errors report the path of the failing node",
+			schema:   `{"friends": [(3,3), {"name": ok, "phone": fail}]}`,
+			wantPath: "$.friends[0].phone",
+			wantErr:  errLookup,
+		},
+		{
+			name:     "Nil generators are reported",
+			schema:   `{"a": ok | ok, "b": [nil]}`,
+			wantPath: "$.b[0]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewParser([]byte(tt.schema))
+			p.TerminalGenerators = generators
+			s, err := p.Parse()
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			got, err := s.GenerateE(context.Background(), rand.New(rand.NewSource(1)))
+			if tt.wantPath == "" {
+				if err != nil {
+					t.Fatalf("GenerateE() error = %v", err)
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("GenerateE() = %v, want %v", got, tt.want)
+				}
+				return
+			}
+
+			var ge *GenerateError
+			if !errors.As(err, &ge) {
+				t.Fatalf("GenerateE() error = %v, want *GenerateError", err)
+			}
+			if ge.Path != tt.wantPath {
+				t.Errorf("GenerateE() path = %s, want %s", ge.Path, tt.wantPath)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("GenerateE() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSchema_GenerateContext(t *testing.T) {
+	s, err := NewDefaultParser([]byte(`[(1000000,1000000), {"name": name}]`)).Parse()
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	s.Root.(Array).Inner.(Object).Values[0].Value = TerminalGenerator{
+		Name: "name",
+		fn: RandGeneratorFunc(func(r *rand.Rand) interface{} {
+			if calls++; calls == 10 {
+				cancel()
+			}
+			return "x"
+		}),
+	}
+
+	_, err = s.GenerateContext(ctx)
+	var ge *GenerateError
+	if !errors.As(err, &ge) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("GenerateContext() error = %v, want cancellation", err)
+	}
+	if ge.Path != "$[10]" || calls != 10 {
+		t.Errorf("GenerateContext() stopped at %s after %d calls", ge.Path, calls)
+	}
+}
+
+// plainNode implements only Generator, as Node implementations outside the
+// package may.
+type plainNode struct{}
+
+func (plainNode) Generate() interface{} { return "plain" }
+
+func TestSchema_GenerateRand_PlainNode(t *testing.T) {
+	obj := Object{}
+	obj.AppendPair("a", plainNode{})
+	s := Schema{Root: Array{Range: &Range{Min: 2, Max: 2}, Inner: obj}}
+
+	got := s.GenerateRand(rand.New(rand.NewSource(1))).([]interface{})
+	if len(got) != 2 || got[0].(*OrderedMap).Values["a"] != "plain" {
+		t.Errorf("GenerateRand() = %v", got)
+	}
+}
@@ -1,6 +1,11 @@
 package sham
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
 	"time"
 
 	"github.com/mattmeyers/sham/gen"
@@ -15,21 +20,470 @@ type Generator interface {
 	Generate() interface{}
 }
 
+// RandGenerator is implemented by generators that draw their random decisions
+// from a provided source rather than the global math/rand source. Seeding the
+// source makes generation reproducible without touching global state. Generators
+// that only implement Generator continue to use the global source.
+type RandGenerator interface {
+	GenerateRand(r *rand.Rand) interface{}
+}
+
+// ErrGenerator is implemented by generators that can fail, such as generators
+// performing I/O. The context carries deadlines and cancellation from
+// Schema.GenerateE. When an ErrGenerator is used through Generate or
+// GenerateRand, a failure causes a panic.
+type ErrGenerator interface {
+	GenerateE(ctx context.Context, r *rand.Rand) (interface{}, error)
+}
+
+// ArgGenerator is implemented by terminal generators that accept arguments,
+// written in a schema as words(5) or email("example.com"). WithArgs receives
+// the arguments as ints, float64s, strings, bools or nil and returns the
+// generator to use in place of the ArgGenerator. Without arguments the
+// ArgGenerator itself is used.
+type ArgGenerator interface {
+	Generator
+	WithArgs(args []interface{}) (Generator, error)
+}
+
+// NewArgGenerator returns an ArgGenerator that generates values with g when
+// used without arguments, and builds a generator with withArgs otherwise.
+func NewArgGenerator(g Generator, withArgs func(args []interface{}) (Generator, error)) ArgGenerator {
+	return argGenerator{g: g, withArgs: withArgs}
+}
+
+type argGenerator struct {
+	g        Generator
+	withArgs func(args []interface{}) (Generator, error)
+}
+
+func (a argGenerator) Generate() interface{} { return a.g.Generate() }
+
+func (a argGenerator) GenerateRand(r *rand.Rand) interface{} { return generateRand(a.g, r) }
+
+func (a argGenerator) GenerateE(ctx context.Context, r *rand.Rand) (interface{}, error) {
+	return generateE(ctx, a.g, r)
+}
+
+func (a argGenerator) WithArgs(args []interface{}) (Generator, error) { return a.withArgs(args) }
+
+// LocaleGenerator is implemented by terminal generators whose output depends
+// on the locale, such as names and addresses. WithLocale returns the generator
+// to use for the locale chosen by the parser or schema.
+type LocaleGenerator interface {
+	Generator
+	WithLocale(l *gen.Locale) Generator
+}
+
+// localeAdaptor builds a LocaleGenerator from a function returning the
+// generator for a locale. The default locale is used until another is chosen.
+func localeAdaptor(f func(l *gen.Locale) Generator) LocaleGenerator {
+	return localeGenerator{g: f(gen.DefaultLocale), f: f}
+}
+
+// localeStringAdaptor generates strings with a method of gen.Locale, such as
+// (*gen.Locale).Name.
+func localeStringAdaptor(m func(*gen.Locale, *rand.Rand) string) LocaleGenerator {
+	return localeAdaptor(func(l *gen.Locale) Generator {
+		return stringAdaptor(func(r *rand.Rand) string { return m(l, r) })
+	})
+}
+
+type localeGenerator struct {
+	g Generator
+	f func(l *gen.Locale) Generator
+}
+
+func (l localeGenerator) Generate() interface{} { return l.g.Generate() }
+
+func (l localeGenerator) GenerateRand(r *rand.Rand) interface{} { return generateRand(l.g, r) }
+
+func (l localeGenerator) WithLocale(locale *gen.Locale) Generator { return l.f(locale) }
+
 // GeneratorFunc is a simple function type that implements the Generator interface.
 // This type can be used to provide single functions as Generators.
 type GeneratorFunc func() interface{}
 
 func (f GeneratorFunc) Generate() interface{} { return f() }
 
-func stringAdaptor(f func() string) func() interface{}  { return func() interface{} { return f() } }
-func intAdaptor(f func() int) func() interface{}        { return func() interface{} { return f() } }
-func timeAdaptor(f func() time.Time) func() interface{} { return func() interface{} { return f() } }
+// RandGeneratorFunc is a function type that implements both the Generator and
+// RandGenerator interfaces. When used as a plain Generator, the global source
+// is used.
+type RandGeneratorFunc func(r *rand.Rand) interface{}
+
+func (f RandGeneratorFunc) Generate() interface{} { return f(globalRand) }
+
+func (f RandGeneratorFunc) GenerateRand(r *rand.Rand) interface{} { return f(r) }
+
+// ErrGeneratorFunc is a function type that implements the Generator,
+// RandGenerator and ErrGenerator interfaces. Generate and GenerateRand panic if
+// the function returns an error.
+type ErrGeneratorFunc func(ctx context.Context, r *rand.Rand) (interface{}, error)
+
+func (f ErrGeneratorFunc) Generate() interface{} { return f.GenerateRand(globalRand) }
+
+func (f ErrGeneratorFunc) GenerateRand(r *rand.Rand) interface{} {
+	v, err := f(context.Background(), r)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (f ErrGeneratorFunc) GenerateE(ctx context.Context, r *rand.Rand) (interface{}, error) {
+	return f(ctx, r)
+}
+
+// generateE runs a generator with the provided context and source, adapting
+// generators that cannot fail.
+func generateE(ctx context.Context, g Generator, r *rand.Rand) (interface{}, error) {
+	if g == nil {
+		return nil, errors.New("nil generator")
+	}
+	if eg, ok := g.(ErrGenerator); ok {
+		return eg.GenerateE(ctx, r)
+	}
+	return generateRand(g, r), nil
+}
+
+// generateRand runs a generator with the provided source if it supports one.
+func generateRand(g Generator, r *rand.Rand) interface{} {
+	if rg, ok := g.(RandGenerator); ok {
+		return rg.GenerateRand(r)
+	}
+	return g.Generate()
+}
+
+// globalRand draws from the top level math/rand functions. It allows Generate
+// to share the global source, and its seed, with the rest of the program.
+var globalRand = gen.GlobalRand()
+
+func stringAdaptor(f func(*rand.Rand) string) RandGeneratorFunc {
+	return func(r *rand.Rand) interface{} { return f(r) }
+}
+
+func intAdaptor(f func(*rand.Rand) int) RandGeneratorFunc {
+	return func(r *rand.Rand) interface{} { return f(r) }
+}
+
+func timeAdaptor(f func(*rand.Rand) time.Time) RandGeneratorFunc {
+	return func(r *rand.Rand) interface{} { return f(r) }
+}
+
+func boolAdaptor(f func(*rand.Rand) bool) RandGeneratorFunc {
+	return func(r *rand.Rand) interface{} { return f(r) }
+}
+
+func floatAdaptor(f func(*rand.Rand) float64) RandGeneratorFunc {
+	return func(r *rand.Rand) interface{} { return f(r) }
+}
+
+// moneyAdaptor generates an amount of money as an object, keyed in the same
+// way as the JSON encoding of gen.MonetaryAmount.
+func moneyAdaptor(f func(*rand.Rand) gen.MonetaryAmount) RandGeneratorFunc {
+	return func(r *rand.Rand) interface{} {
+		m := f(r)
+
+		o := NewOrderedMap()
+		o.Set("amount", m.Amount)
+		o.Set("currency", m.Currency)
+		return o
+	}
+}
+
+// lengthAdaptor generates strings with f, passing a length chosen between min
+// and max. The range can be replaced in a schema with a single argument giving
+// an exact length, as in words(5), or two giving a range, as in words(3, 8).
+func lengthAdaptor(f func(*rand.Rand, int) string, min, max int) ArgGenerator {
+	g := func(min, max int) RandGeneratorFunc {
+		return func(r *rand.Rand) interface{} {
+			if min == max {
+				return f(r, min)
+			}
+			return f(r, min+r.Intn(max-min+1))
+		}
+	}
+
+	return NewArgGenerator(g(min, max), func(args []interface{}) (Generator, error) {
+		var lo, hi int
+		var err error
+		switch len(args) {
+		case 1:
+			lo, err = intArg(args, 0)
+			hi = lo
+		case 2:
+			if lo, err = intArg(args, 0); err == nil {
+				hi, err = intArg(args, 1)
+			}
+		default:
+			return nil, fmt.Errorf("expected 1 or 2 arguments, got %d", len(args))
+		}
+
+		if err != nil {
+			return nil, err
+		} else if lo < 0 || hi < lo {
+			return nil, fmt.Errorf("invalid length range (%d,%d)", lo, hi)
+		}
+		return g(lo, hi), nil
+	})
+}
+
+// stringArgAdaptor generates strings with f, or with withArg when given a
+// single string argument.
+func stringArgAdaptor(f func(*rand.Rand) string, withArg func(*rand.Rand, string) string) ArgGenerator {
+	return NewArgGenerator(stringAdaptor(f), func(args []interface{}) (Generator, error) {
+		s, err := singleStringArg(args)
+		if err != nil {
+			return nil, err
+		}
+		return stringAdaptor(func(r *rand.Rand) string { return withArg(r, s) }), nil
+	})
+}
+
+// checkedStringArgAdaptor is a stringArgAdaptor whose argument can be rejected
+// by withArg. The argument is checked once when the schema is parsed, so
+// generation cannot fail.
+func checkedStringArgAdaptor(f func(*rand.Rand) string, withArg func(*rand.Rand, string) (string, error)) ArgGenerator {
+	return NewArgGenerator(stringAdaptor(f), func(args []interface{}) (Generator, error) {
+		s, err := singleStringArg(args)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := withArg(rand.New(rand.NewSource(0)), s); err != nil {
+			return nil, err
+		}
+
+		return stringAdaptor(func(r *rand.Rand) string {
+			v, _ := withArg(r, s)
+			return v
+		}), nil
+	})
+}
+
+// networkAdaptor generates IP addresses with f, or within the network given
+// as an argument in CIDR notation, as in ipv4("10.0.0.0/8"). The network must
+// belong to the given IP version.
+func networkAdaptor(f func(*rand.Rand) string, version int) ArgGenerator {
+	return NewArgGenerator(stringAdaptor(f), func(args []interface{}) (Generator, error) {
+		cidr, err := singleStringArg(args)
+		if err != nil {
+			return nil, err
+		}
+
+		ip, _, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		} else if (ip.To4() != nil) != (version == 4) {
+			return nil, fmt.Errorf("%s is not an IPv%d network", cidr, version)
+		}
+
+		return stringAdaptor(func(r *rand.Rand) string {
+			s, _ := gen.IPInNetwork(r, cidr)
+			return s
+		}), nil
+	})
+}
+
+// priceAdaptor generates prices with f, or between the two bounds given as
+// arguments, as in price(5, 49.99).
+func priceAdaptor(f func(*rand.Rand) string, between func(*rand.Rand, float64, float64) string) ArgGenerator {
+	return NewArgGenerator(stringAdaptor(f), func(args []interface{}) (Generator, error) {
+		if len(args) != 2 {
+			return nil, fmt.Errorf("expected 2 arguments, got %d", len(args))
+		}
+
+		min, err := numberArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		max, err := numberArg(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		if min < 0 || max < min {
+			return nil, fmt.Errorf("invalid price range (%v,%v)", min, max)
+		}
+
+		return stringAdaptor(func(r *rand.Rand) string { return between(r, min, max) }), nil
+	})
+}
+
+// floatAdaptorBetween generates numbers with f, or between the two bounds
+// given as arguments, as in float(0.5, 2).
+func floatAdaptorBetween(f func(*rand.Rand) float64, between func(*rand.Rand, float64, float64) float64) ArgGenerator {
+	return NewArgGenerator(floatAdaptor(f), func(args []interface{}) (Generator, error) {
+		if len(args) != 2 {
+			return nil, fmt.Errorf("expected 2 arguments, got %d", len(args))
+		}
+
+		min, err := numberArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		max, err := numberArg(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		if max < min {
+			return nil, fmt.Errorf("invalid range (%v,%v)", min, max)
+		}
+
+		return floatAdaptor(func(r *rand.Rand) float64 { return between(r, min, max) }), nil
+	})
+}
+
+func singleStringArg(args []interface{}) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("expected 1 argument, got %d", len(args))
+	}
+	return stringArg(args, 0)
+}
+
+func intArg(args []interface{}, i int) (int, error) {
+	n, ok := args[i].(int)
+	if !ok {
+		return 0, fmt.Errorf("argument %d: expected an integer, got %v", i+1, args[i])
+	}
+	return n, nil
+}
+
+func numberArg(args []interface{}, i int) (float64, error) {
+	switch n := args[i].(type) {
+	case int:
+		return float64(n), nil
+	case float64:
+		return n, nil
+	}
+	return 0, fmt.Errorf("argument %d: expected a number, got %v", i+1, args[i])
+}
+
+func stringArg(args []interface{}, i int) (string, error) {
+	s, ok := args[i].(string)
+	if !ok {
+		return "", fmt.Errorf("argument %d: expected a string, got %v", i+1, args[i])
+	}
+	return s, nil
+}
+
+// addressAdaptor generates an address as an object, keyed in the same way as
+// the JSON encoding of gen.PostalAddress.
+func addressAdaptor(f func(*rand.Rand) gen.PostalAddress) RandGeneratorFunc {
+	return func(r *rand.Rand) interface{} {
+		a := f(r)
+
+		m := NewOrderedMap()
+		m.Set("street", a.Street)
+		m.Set("city", a.City)
+		m.Set("state", a.State)
+		m.Set("stateCode", a.StateCode)
+		m.Set("postalCode", a.PostalCode)
+		m.Set("country", a.Country)
+		m.Set("countryCode", a.CountryCode)
+		m.Set("latitude", a.Latitude)
+		m.Set("longitude", a.Longitude)
+		return m
+	}
+}
+
+// personAdaptor generates a person as an object, keyed in the same way as the
+// JSON encoding of gen.Profile.
+func personAdaptor(f func(*rand.Rand) gen.Profile) RandGeneratorFunc {
+	return func(r *rand.Rand) interface{} {
+		p := f(r)
+
+		m := NewOrderedMap()
+		m.Set("firstName", p.FirstName)
+		m.Set("lastName", p.LastName)
+		m.Set("fullName", p.FullName)
+		m.Set("gender", p.Gender)
+		if p.Prefix != "" {
+			m.Set("prefix", p.Prefix)
+		}
+		m.Set("email", p.Email)
+		m.Set("birthdate", p.Birthdate)
+		m.Set("age", p.Age)
+		if p.Avatar != "" {
+			m.Set("avatar", p.Avatar)
+		}
+		return m
+	}
+}
 
 // TerminalGenerators is the standard collection of terminal generators provided by Sham.
 var TerminalGenerators = map[string]Generator{
-	"name":        GeneratorFunc(stringAdaptor(gen.Name)),
-	"firstName":   GeneratorFunc(stringAdaptor(gen.FirstName)),
-	"lastName":    GeneratorFunc(stringAdaptor(gen.LastName)),
-	"phoneNumber": GeneratorFunc(stringAdaptor(gen.PhoneNumber)),
-	"timestamp":   GeneratorFunc(timeAdaptor(gen.Timestamp)),
+	"person":      localeAdaptor(func(l *gen.Locale) Generator { return personAdaptor(l.Person) }),
+	"name":        localeStringAdaptor((*gen.Locale).Name),
+	"firstName":   localeStringAdaptor((*gen.Locale).FirstName),
+	"lastName":    localeStringAdaptor((*gen.Locale).LastName),
+	"phoneNumber": localeStringAdaptor((*gen.Locale).PhoneNumber),
+	"timestamp":   timeAdaptor(gen.TimestampRand),
+	"boolean":     boolAdaptor(gen.Bool),
+	"float":       floatAdaptorBetween(gen.Float, gen.FloatBetween),
+	"email":       stringArgAdaptor(gen.Email, gen.EmailAt),
+	"username":    stringAdaptor(gen.Username),
+	"domain":      stringArgAdaptor(gen.Domain, gen.DomainWithTLD),
+	"url":         stringAdaptor(gen.URL),
+	"ipv4":        networkAdaptor(gen.IPv4, 4),
+	"ipv6":        networkAdaptor(gen.IPv6, 6),
+	"macAddress":  stringAdaptor(gen.MacAddress),
+	"userAgent":   stringAdaptor(gen.UserAgent),
+	"slug":        stringAdaptor(gen.Slug),
+	"password":    lengthAdaptor(gen.PasswordOfLength, 12, 20),
+
+	"address":       localeAdaptor(func(l *gen.Locale) Generator { return addressAdaptor(l.Address) }),
+	"streetAddress": localeStringAdaptor((*gen.Locale).StreetAddress),
+	"city":          localeStringAdaptor((*gen.Locale).City),
+	"state":         localeStringAdaptor((*gen.Locale).State),
+	"region":        localeStringAdaptor((*gen.Locale).State),
+	"stateCode":     localeStringAdaptor((*gen.Locale).StateCode),
+	"postalCode":    localeStringAdaptor((*gen.Locale).PostalCode),
+	"country":       stringAdaptor(gen.Country),
+	"countryCode":   stringAdaptor(gen.CountryCode),
+	"latitude":      floatAdaptor(gen.Latitude),
+	"longitude":     floatAdaptor(gen.Longitude),
+
+	"uuid":   stringAdaptor(gen.UUID),
+	"uuidv4": stringAdaptor(gen.UUIDv4),
+	"uuidv7": stringAdaptor(gen.UUIDv7),
+	"ulid":   stringAdaptor(gen.ULID),
+	"ksuid":  stringAdaptor(gen.KSUID),
+	"nanoid": lengthAdaptor(gen.NanoIDOfLength, 21, 21),
+
+	"creditCard":    checkedStringArgAdaptor(gen.CreditCard, gen.CreditCardFor),
+	"iban":          checkedStringArgAdaptor(gen.IBAN, gen.IBANFor),
+	"bic":           stringAdaptor(gen.BIC),
+	"routingNumber": stringAdaptor(gen.RoutingNumber),
+	"currencyCode":  stringAdaptor(gen.CurrencyCode),
+	"amount":        checkedStringArgAdaptor(gen.Amount, gen.AmountIn),
+	"money":         moneyAdaptor(gen.Money),
+
+	"date":       dateAdaptor(gen.Date, "2006-01-02"),
+	"time":       clockAdaptor(gen.TimeOfDay),
+	"datetime":   dateAdaptor(gen.DateTime, time.RFC3339),
+	"duration":   durationAdaptor(gen.Duration),
+	"unixTime":   unixAdaptor(gen.UnixTime, time.Second),
+	"unixMillis": unixAdaptor(gen.UnixMillis, time.Millisecond),
+	"past":       shortcutTimeAdaptor(gen.Past),
+	"future":     shortcutTimeAdaptor(gen.Future),
+	"recent":     shortcutTimeAdaptor(gen.Recent),
+
+	"company":       stringAdaptor(gen.Company),
+	"companySuffix": stringAdaptor(gen.CompanySuffix),
+	"catchPhrase":   stringAdaptor(gen.CatchPhrase),
+	"jobTitle":      stringAdaptor(gen.JobTitle),
+	"department":    stringAdaptor(gen.Department),
+	"productName":   stringAdaptor(gen.ProductName),
+	"sku":           stringAdaptor(gen.SKU),
+	"price":         priceAdaptor(gen.Price, gen.PriceBetween),
+	"ean13":         stringAdaptor(gen.EAN13),
+	"upc":           stringAdaptor(gen.UPC),
+	"isbn10":        stringAdaptor(gen.ISBN10),
+	"isbn13":        stringAdaptor(gen.ISBN13),
+
+	"word":      stringAdaptor(gen.Word),
+	"words":     lengthAdaptor(gen.WordsOfLength, 2, 6),
+	"sentence":  lengthAdaptor(gen.SentenceOfLength, 4, 12),
+	"paragraph": lengthAdaptor(gen.ParagraphOfLength, 3, 6),
+	"title":     lengthAdaptor(gen.TitleOfLength, 2, 5),
+	"markdown":  lengthAdaptor(gen.MarkdownOfLength, 2, 4),
 }
@@ -0,0 +1,298 @@
+package sham
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestArgGenerator(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		want    string
+		valid   func(v interface{}) bool
+		wantErr string
+	}{
+		{
+			name:   "Exact length",
+			schema: `words(4)`,
+			want:   `words(4)`,
+			valid:  func(v interface{}) bool { return len(strings.Fields(v.(string))) == 4 },
+		},
+		{
+			name:   "Length range",
+			schema: `sentence( 2 , 3 )`,
+			want:   `sentence(2, 3)`,
+			valid: func(v interface{}) bool {
+				n := len(strings.Fields(v.(string)))
+				return n == 2 || n == 3
+			},
+		},
+		{
+			name:   "Empty arguments",
+			schema: `word()`,
+			want:   `word`,
+			valid:  func(v interface{}) bool { return regexp.MustCompile(`^[a-z]+$`).MatchString(v.(string)) },
+		},
+		{
+			name:   "String argument",
+			schema: `email("example.com")`,
+			want:   `email("example.com")`,
+			valid:  func(v interface{}) bool { return strings.HasSuffix(v.(string), "@example.com") },
+		},
+		{
+			name:   "Network argument",
+			schema: `ipv4("192.168.0.0/16")`,
+			want:   `ipv4("192.168.0.0/16")`,
+			valid: func(v interface{}) bool {
+				_, n, _ := net.ParseCIDR("192.168.0.0/16")
+				return n.Contains(net.ParseIP(v.(string)))
+			},
+		},
+		{
+			name:   "Checked string argument",
+			schema: `amount("JPY")`,
+			want:   `amount("JPY")`,
+			valid:  func(v interface{}) bool { return regexp.MustCompile(`^\d+$`).MatchString(v.(string)) },
+		},
+		{
+			name:   "Number arguments",
+			schema: `price(5, 49.99)`,
+			want:   `price(5, 49.99)`,
+			valid: func(v interface{}) bool {
+				f, err := strconv.ParseFloat(v.(string), 64)
+				return err == nil && f >= 5 && f <= 49.99
+			},
+		},
+		{
+			name:    "Rejected string argument",
+			schema:  `creditCard("bank")`,
+			wantErr: `invalid arguments to creditCard: unknown card network "bank"`,
+		},
+		{
+			name:    "Generator without arguments",
+			schema:  `name(2)`,
+			wantErr: `terminal generator "name" does not accept arguments`,
+		},
+		{
+			name:    "Wrong argument type",
+			schema:  `words("a")`,
+			wantErr: "invalid arguments to words: argument 1: expected an integer, got a",
+		},
+		{
+			name:    "Too many arguments",
+			schema:  `paragraph(1, 2, 3)`,
+			wantErr: "invalid arguments to paragraph: expected 1 or 2 arguments, got 3",
+		},
+		{
+			name:    "Invalid range",
+			schema:  `title(3, 1)`,
+			wantErr: "invalid arguments to title: invalid length range (3,1)",
+		},
+		{
+			name:    "Invalid price range",
+			schema:  `price(10, 1)`,
+			wantErr: "invalid arguments to price: invalid price range (10,1)",
+		},
+		{
+			name:   "Float bounds",
+			schema: `float(0.5, 2)`,
+			want:   `float(0.5, 2)`,
+			valid: func(v interface{}) bool {
+				f := v.(float64)
+				return f > 0.5 && f < 2
+			},
+		},
+		{
+			name:    "Reversed float bounds",
+			schema:  `float(2, 1.5)`,
+			wantErr: "invalid arguments to float: invalid range (2,1.5)",
+		},
+		{
+			name:    "Wrong IP version",
+			schema:  `ipv6("10.0.0.0/8")`,
+			wantErr: "invalid arguments to ipv6: 10.0.0.0/8 is not an IPv6 network",
+		},
+		{
+			name:    "Unterminated arguments",
+			schema:  `words(1,`,
+			wantErr: "expected argument, got {<EOF> }",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewDefaultParser([]byte(tt.schema)).Parse()
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("Parse() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			if got := s.String(); got != tt.want {
+				t.Errorf("String() = %s, want %s", got, tt.want)
+			}
+
+			r := rand.New(rand.NewSource(1))
+			for i := 0; i < 50; i++ {
+				if v := s.GenerateRand(r); !tt.valid(v) {
+					t.Fatalf("GenerateRand() = %v", v)
+				}
+			}
+		})
+	}
+}
+
+func TestArgGenerator_Concurrent(t *testing.T) {
+	schemas := []struct {
+		schema   string
+		min, max int
+	}{
+		{schema: `words(3)`, min: 3, max: 3},
+		{schema: `words(7, 9)`, min: 7, max: 9},
+		{schema: `words`, min: 2, max: 6},
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan string, len(schemas)*20)
+	for i := 0; i < 20; i++ {
+		for _, tt := range schemas {
+			wg.Add(1)
+			go func(schema string, min, max int) {
+				defer wg.Done()
+
+				s, err := NewDefaultParser([]byte(schema)).Parse()
+				if err != nil {
+					errs <- err.Error()
+					return
+				}
+
+				r := rand.New(rand.NewSource(1))
+				for j := 0; j < 20; j++ {
+					if n := len(strings.Fields(s.GenerateRand(r).(string))); n < min || n > max {
+						errs <- fmt.Sprintf("%s generated %d words", schema, n)
+						return
+					}
+				}
+			}(tt.schema, tt.min, tt.max)
+		}
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestLocaleGenerator(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		locale  string
+		want    string
+		valid   *regexp.Regexp
+		wantErr string
+	}{
+		{
+			name:   "Default locale",
+			schema: `postalCode`,
+			want:   `postalCode`,
+			valid:  regexp.MustCompile(`^\d{5}$`),
+		},
+		{
+			name:   "Parser locale",
+			schema: `postalCode`,
+			locale: "ja_JP",
+			want:   `@locale("ja_JP")` + "\n" + `postalCode`,
+			valid:  regexp.MustCompile(`^\d{3}-\d{4}$`),
+		},
+		{
+			name:   "Directive",
+			schema: `@locale("pt_BR") postalCode`,
+			want:   `@locale("pt_BR")` + "\n" + `postalCode`,
+			valid:  regexp.MustCompile(`^\d{5}-\d{3}$`),
+		},
+		{
+			name:   "Directive takes precedence",
+			schema: `@locale("pt-BR") postalCode`,
+			locale: "ja_JP",
+			want:   `@locale("pt-BR")` + "\n" + `postalCode`,
+			valid:  regexp.MustCompile(`^\d{5}-\d{3}$`),
+		},
+		{
+			name:    "Unknown locale",
+			schema:  `postalCode`,
+			locale:  "xx_XX",
+			wantErr: `unknown locale "xx_XX", expected one of de_DE, en_US, fr_FR, ja_JP, pt_BR`,
+		},
+		{
+			name:    "Unknown directive",
+			schema:  `@seed(1) postalCode`,
+			wantErr: "unknown directive @seed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewDefaultParser([]byte(tt.schema))
+			p.Locale = tt.locale
+			s, err := p.Parse()
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("Parse() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			if got := s.String(); got != tt.want {
+				t.Errorf("String() = %s, want %s", got, tt.want)
+			}
+
+			r := rand.New(rand.NewSource(1))
+			for i := 0; i < 50; i++ {
+				if v, ok := s.GenerateRand(r).(string); !ok || !tt.valid.MatchString(v) {
+					t.Fatalf("GenerateRand() = %v", v)
+				}
+			}
+		})
+	}
+}
+
+func TestPersonGenerator(t *testing.T) {
+	p := NewDefaultParser([]byte(`person`))
+	p.Locale = "ja_JP"
+	s, err := p.Parse()
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	email := regexp.MustCompile(`^[a-z0-9._]+@[a-z.]+$`)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		m, ok := s.GenerateRand(r).(*OrderedMap)
+		if !ok {
+			t.Fatalf("GenerateRand() = %v, want an object", m)
+		}
+
+		keys := strings.Join(m.Keys, ",")
+		if keys != "firstName,lastName,fullName,gender,email,birthdate,age" && keys != "firstName,lastName,fullName,gender,email,birthdate,age,avatar" {
+			t.Fatalf("GenerateRand() keys = %s", keys)
+		}
+		if m.Values["fullName"] != m.Values["lastName"].(string)+" "+m.Values["firstName"].(string) {
+			t.Fatalf("GenerateRand() = %v, want the family name first", m.Values)
+		}
+		if !email.MatchString(m.Values["email"].(string)) {
+			t.Fatalf("GenerateRand() email = %v, want a romanized address", m.Values["email"])
+		}
+	}
+}
@@ -1,3 +1,5 @@
 module github.com/mattmeyers/sham
 
 go 1.15
+
+require gopkg.in/yaml.v3 v3.0.1
@@ -0,0 +1,633 @@
+// Package gogen generates Go source code from a Sham schema. The generated code
+// declares struct types matching the shape of the schema along with a
+// constructor that generates random values directly, without building the
+// interface{} tree produced by Schema.Generate.
+package gogen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/mattmeyers/sham"
+)
+
+var errEmptySchema = errors.New("empty schema")
+
+// Options configures the generated code.
+type Options struct {
+	// Package is the name of the generated package. Defaults to "main".
+	Package string
+	// Type is the name of the type generated for the root of the schema.
+	// Defaults to "Data".
+	Type string
+}
+
+// terminal describes how a terminal generator is called from generated code.
+type terminal struct {
+	typ string
+	fn  string
+}
+
+// terminals maps the default terminal generators to the gen functions that
+// implement them. Schemas referencing other terminal generators cannot be
+// converted.
+var terminals = map[string]terminal{
+	"person":      {"gen.Profile", "gen.Person"},
+	"name":        {"string", "gen.NameRand"},
+	"firstName":   {"string", "gen.FirstNameRand"},
+	"lastName":    {"string", "gen.LastNameRand"},
+	"phoneNumber": {"string", "gen.PhoneNumberRand"},
+	"timestamp":   {"time.Time", "gen.TimestampRand"},
+	"boolean":     {"bool", "gen.Bool"},
+	"float":       {"float64", "gen.Float"},
+	"email":       {"string", "gen.Email"},
+	"username":    {"string", "gen.Username"},
+	"domain":      {"string", "gen.Domain"},
+	"url":         {"string", "gen.URL"},
+	"ipv4":        {"string", "gen.IPv4"},
+	"ipv6":        {"string", "gen.IPv6"},
+	"macAddress":  {"string", "gen.MacAddress"},
+	"userAgent":   {"string", "gen.UserAgent"},
+	"slug":        {"string", "gen.Slug"},
+	"password":    {"string", "gen.Password"},
+
+	"address":       {"gen.PostalAddress", "gen.Address"},
+	"streetAddress": {"string", "gen.StreetAddress"},
+	"city":          {"string", "gen.City"},
+	"state":         {"string", "gen.State"},
+	"region":        {"string", "gen.State"},
+	"stateCode":     {"string", "gen.StateCode"},
+	"postalCode":    {"string", "gen.PostalCode"},
+	"country":       {"string", "gen.Country"},
+	"countryCode":   {"string", "gen.CountryCode"},
+	"latitude":      {"float64", "gen.Latitude"},
+	"longitude":     {"float64", "gen.Longitude"},
+
+	"uuid":   {"string", "gen.UUID"},
+	"uuidv4": {"string", "gen.UUIDv4"},
+	"uuidv7": {"string", "gen.UUIDv7"},
+	"ulid":   {"string", "gen.ULID"},
+	"ksuid":  {"string", "gen.KSUID"},
+	"nanoid": {"string", "gen.NanoID"},
+
+	"creditCard":    {"string", "gen.CreditCard"},
+	"iban":          {"string", "gen.IBAN"},
+	"bic":           {"string", "gen.BIC"},
+	"routingNumber": {"string", "gen.RoutingNumber"},
+	"currencyCode":  {"string", "gen.CurrencyCode"},
+	"amount":        {"string", "gen.Amount"},
+	"money":         {"gen.MonetaryAmount", "gen.Money"},
+
+	"date":       {"string", "gen.Date"},
+	"time":       {"string", "gen.TimeOfDay"},
+	"datetime":   {"string", "gen.DateTime"},
+	"duration":   {"time.Duration", "gen.Duration"},
+	"unixTime":   {"int64", "gen.UnixTime"},
+	"unixMillis": {"int64", "gen.UnixMillis"},
+	"past":       {"time.Time", "gen.Past"},
+	"future":     {"time.Time", "gen.Future"},
+	"recent":     {"time.Time", "gen.Recent"},
+
+	"company":       {"string", "gen.Company"},
+	"companySuffix": {"string", "gen.CompanySuffix"},
+	"catchPhrase":   {"string", "gen.CatchPhrase"},
+	"jobTitle":      {"string", "gen.JobTitle"},
+	"department":    {"string", "gen.Department"},
+	"productName":   {"string", "gen.ProductName"},
+	"sku":           {"string", "gen.SKU"},
+	"price":         {"string", "gen.Price"},
+	"ean13":         {"string", "gen.EAN13"},
+	"upc":           {"string", "gen.UPC"},
+	"isbn10":        {"string", "gen.ISBN10"},
+	"isbn13":        {"string", "gen.ISBN13"},
+
+	"word":      {"string", "gen.Word"},
+	"words":     {"string", "gen.Words"},
+	"sentence":  {"string", "gen.Sentence"},
+	"paragraph": {"string", "gen.Paragraph"},
+	"title":     {"string", "gen.Title"},
+	"markdown":  {"string", "gen.Markdown"},
+}
+
+// localized maps the terminal generators whose values depend on the locale to
+// the methods of gen.Locale producing them, which are called through the
+// schema's locale.
+var localized = map[string]string{
+	"person":        "Person",
+	"name":          "Name",
+	"firstName":     "FirstName",
+	"lastName":      "LastName",
+	"phoneNumber":   "PhoneNumber",
+	"address":       "Address",
+	"streetAddress": "StreetAddress",
+	"city":          "City",
+	"state":         "State",
+	"region":        "State",
+	"stateCode":     "StateCode",
+	"postalCode":    "PostalCode",
+}
+
+// argTerminals builds the calls used for terminal generators given arguments
+// in the schema. The parser has already validated the arguments.
+var argTerminals = map[string]func(args []interface{}) string{
+	"email":     stringArgCall("gen.EmailAt"),
+	"domain":    stringArgCall("gen.DomainWithTLD"),
+	"password":  lengthCall("gen.PasswordOfLength"),
+	"nanoid":    lengthCall("gen.NanoIDOfLength"),
+	"words":     lengthCall("gen.WordsOfLength"),
+	"sentence":  lengthCall("gen.SentenceOfLength"),
+	"paragraph": lengthCall("gen.ParagraphOfLength"),
+	"title":     lengthCall("gen.TitleOfLength"),
+	"markdown":  lengthCall("gen.MarkdownOfLength"),
+	"price":     priceCall,
+	"float":     floatCall,
+}
+
+// stringArgCall calls fn with the single string argument.
+func stringArgCall(fn string) func(args []interface{}) string {
+	return func(args []interface{}) string {
+		return fmt.Sprintf("%s(r, %s)", fn, strconv.Quote(args[0].(string)))
+	}
+}
+
+// lengthCall calls fn with the exact length, or a length chosen from the range,
+// given by the arguments.
+func lengthCall(fn string) func(args []interface{}) string {
+	return func(args []interface{}) string {
+		min, max := args[0].(int), args[len(args)-1].(int)
+		if min == max {
+			return fmt.Sprintf("%s(r, %d)", fn, min)
+		}
+		return fmt.Sprintf("%s(r, r.Intn(%d)+%d)", fn, max-min+1, min)
+	}
+}
+
+// priceCall calls gen.PriceBetween with the bounds given by the arguments.
+func priceCall(args []interface{}) string {
+	return fmt.Sprintf("gen.PriceBetween(r, %v, %v)", args[0], args[1])
+}
+
+// floatCall calls gen.FloatBetween with the bounds given by the arguments.
+func floatCall(args []interface{}) string {
+	return fmt.Sprintf("gen.FloatBetween(r, %v, %v)", args[0], args[1])
+}
+
+// commonInitialisms are written in all caps when converting keys into Go
+// identifiers.
+var commonInitialisms = map[string]bool{
+	"API": true, "HTML": true, "HTTP": true, "ID": true, "IP": true, "JSON": true,
+	"SKU": true, "SQL": true, "URI": true, "URL": true, "UUID": true, "XML": true,
+}
+
+// Generate produces a formatted Go source file for the schema. For a root type
+// named T, the file declares T, any types needed for nested objects, and a
+// function
+//
+//	func NewRandomT(r *rand.Rand) T
+//
+// that draws every random decision from r. Objects become structs with json
+// tags, arrays become slices, ranges become ints, timestamps become time.Time,
+// and regular expressions and formatted strings become strings. Optional keys
+// become pointers with the omitempty option, and choices between a type and
+// null become pointers. Choices between values of different types cannot be
+// represented and result in an error.
+func Generate(s sham.Schema, opts Options) ([]byte, error) {
+	if opts.Package == "" {
+		opts.Package = "main"
+	}
+	if opts.Type == "" {
+		opts.Type = "Data"
+	}
+	if s.Root == nil {
+		return nil, errEmptySchema
+	}
+	if !isIdentifier(opts.Type) {
+		return nil, fmt.Errorf("invalid type name %q", opts.Type)
+	}
+
+	g := &generator{
+		imports: map[string]bool{"math/rand": true},
+		names:   make(map[string]bool),
+		locale:  s.Locale,
+	}
+	g.names[opts.Type] = true
+	g.names["NewRandom"+opts.Type] = true
+
+	if err := g.root(s.Root, opts.Type); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by sham gen-go. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\n", opts.Package)
+
+	// Standard library imports are grouped before the Sham packages.
+	std, other := make([]string, 0), make([]string, 0)
+	for imp := range g.imports {
+		if strings.Contains(strings.Split(imp, "/")[0], ".") {
+			other = append(other, imp)
+		} else {
+			std = append(std, imp)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(other)
+
+	out.WriteString("import (\n")
+	for _, imp := range std {
+		fmt.Fprintf(&out, "\t%q\n", imp)
+	}
+	if len(other) > 0 {
+		out.WriteString("\n")
+	}
+	for _, imp := range other {
+		fmt.Fprintf(&out, "\t%q\n", imp)
+	}
+	out.WriteString(")\n\n")
+
+	out.Write(g.types.Bytes())
+	if g.vars.Len() > 0 {
+		out.WriteString("var (\n")
+		out.Write(g.vars.Bytes())
+		out.WriteString(")\n\n")
+	}
+	out.Write(g.funcs.Bytes())
+
+	return format.Source(out.Bytes())
+}
+
+// generator accumulates the declarations of the generated file. Every
+// identifier declared at the package level is recorded in names so that
+// generated names never collide.
+type generator struct {
+	types   bytes.Buffer
+	vars    bytes.Buffer
+	funcs   bytes.Buffer
+	imports map[string]bool
+	names   map[string]bool
+	// locale is the schema's locale, and localeVar the variable holding it
+	// once a localized terminal generator has been used.
+	locale    string
+	localeVar string
+}
+
+// root declares the root type and its exported constructor. Objects are
+// declared as the root struct itself, while every other node is wrapped in a
+// defined type.
+func (g *generator) root(n sham.Node, name string) error {
+	if obj, ok := n.(sham.Object); ok {
+		return g.object(obj, name, "NewRandom"+name)
+	}
+
+	typ, expr, err := g.expr(n, name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(&g.types, "type %s %s\n\n", name, typ)
+	fmt.Fprintf(&g.funcs, "// NewRandom%s generates a random %s using r for every random decision.\n", name, name)
+	fmt.Fprintf(&g.funcs, "func NewRandom%s(r *rand.Rand) %s {\n\treturn %s(%s)\n}\n\n", name, name, name, expr)
+	return nil
+}
+
+// expr returns the Go type of the values generated by n and an expression
+// producing one such value from the *rand.Rand named r. The name is used as
+// the basis for any declarations needed by the expression.
+func (g *generator) expr(n sham.Node, name string) (string, string, error) {
+	switch n := n.(type) {
+	case sham.Object:
+		typ := g.declare(name)
+		fn := g.declare("newRandom" + typ)
+		if err := g.object(n, typ, fn); err != nil {
+			return "", "", err
+		}
+		return typ, fn + "(r)", nil
+	case sham.Array:
+		return g.array(n, name)
+	case sham.Choice:
+		return g.choice(n, name)
+	case sham.Range:
+		if n.Min == n.Max {
+			return "int", strconv.Itoa(n.Min), nil
+		}
+		return "int", fmt.Sprintf("r.Intn(%d) + %d", n.Max-n.Min+1, n.Min), nil
+	case sham.Regex:
+		v := g.declare(lowerFirst(name) + "Regex")
+		g.imports["github.com/mattmeyers/sham"] = true
+		fmt.Fprintf(&g.vars, "%s = sham.MustRegex(%s)\n", v, strconv.Quote(n.Pattern))
+		return "string", v + ".GenerateString(r)", nil
+	case sham.FormattedString:
+		return g.formattedString(n)
+	case sham.TerminalGenerator:
+		t, err := g.terminal(n.Name)
+		if err != nil {
+			return "", "", err
+		}
+		if len(n.Args) == 0 {
+			return t.typ, t.fn + "(r)", nil
+		}
+
+		call, ok := argTerminals[n.Name]
+		if !ok {
+			return "", "", fmt.Errorf("unsupported arguments to terminal generator %q", n.Name)
+		}
+		return t.typ, call(n.Args), nil
+	case sham.Literal:
+		return literal(n.Value)
+	}
+
+	return "", "", fmt.Errorf("unsupported node %T", n)
+}
+
+func (g *generator) terminal(name string) (terminal, error) {
+	t, ok := terminals[name]
+	if !ok {
+		return terminal{}, fmt.Errorf("unsupported terminal generator %q", name)
+	}
+
+	g.imports["github.com/mattmeyers/sham/gen"] = true
+	if strings.Contains(t.typ, "time.") {
+		g.imports["time"] = true
+	}
+
+	if method, ok := localized[name]; ok && g.locale != "" {
+		if g.localeVar == "" {
+			g.localeVar = g.declare("locale")
+			fmt.Fprintf(&g.vars, "%s = gen.MustLookupLocale(%s)\n", g.localeVar, strconv.Quote(g.locale))
+		}
+		t.fn = g.localeVar + "." + method
+	}
+	return t, nil
+}
+
+// object declares a struct type for the object and a constructor function
+// named fn. Keys repeated within the object use the last value, in the
+// position of the first occurrence, matching Object.Generate.
+func (g *generator) object(o sham.Object, typ, fn string) error {
+	order := make([]string, 0, len(o.Values))
+	last := make(map[string]sham.KV)
+	for _, kv := range o.Values {
+		if _, ok := last[kv.Key]; !ok {
+			order = append(order, kv.Key)
+		}
+		last[kv.Key] = kv
+	}
+
+	fields := make(map[string]bool)
+	var decl, body bytes.Buffer
+	for _, k := range order {
+		kv := last[k]
+		field := uniqueName(fields, exportedName(k))
+		fields[field] = true
+
+		ftyp, expr, err := g.expr(kv.Value, typ+field)
+		if err != nil {
+			return fmt.Errorf("key %q: %w", k, err)
+		}
+
+		if !kv.Optional {
+			fmt.Fprintf(&decl, "\t%s %s `json:%q`\n", field, ftyp, k)
+			fmt.Fprintf(&body, "\tv.%s = %s\n", field, expr)
+			continue
+		}
+
+		if strings.HasPrefix(ftyp, "*") {
+			fmt.Fprintf(&decl, "\t%s %s `json:%q`\n", field, ftyp, k+",omitempty")
+			fmt.Fprintf(&body, "\tif r.Intn(2) != 0 {\n\t\tv.%s = %s\n\t}\n", field, expr)
+		} else {
+			fmt.Fprintf(&decl, "\t%s *%s `json:%q`\n", field, ftyp, k+",omitempty")
+			fmt.Fprintf(&body, "\tif r.Intn(2) != 0 {\n\t\tx := %s\n\t\tv.%s = &x\n\t}\n", expr, field)
+		}
+	}
+
+	fmt.Fprintf(&g.types, "type %s struct {\n%s}\n\n", typ, decl.Bytes())
+
+	if isExported(fn) {
+		fmt.Fprintf(&g.funcs, "// %s generates a random %s using r for every random decision.\n", fn, typ)
+	}
+	fmt.Fprintf(&g.funcs, "func %s(r *rand.Rand) %s {\n\tvar v %s\n%sreturn v\n}\n\n", fn, typ, typ, body.Bytes())
+	return nil
+}
+
+func (g *generator) array(a sham.Array, name string) (string, string, error) {
+	if a.Inner == nil {
+		return "[]interface{}", "[]interface{}{}", nil
+	}
+
+	typ, expr, err := g.expr(a.Inner, singular(name))
+	if err != nil {
+		return "", "", err
+	}
+
+	n := "1"
+	if a.Range != nil {
+		_, n, _ = g.expr(*a.Range, name)
+	}
+
+	fn := g.declare("newRandom" + name)
+	fmt.Fprintf(&g.funcs, "func %s(r *rand.Rand) []%s {\n", fn, typ)
+	fmt.Fprintf(&g.funcs, "\tv := make([]%s, %s)\n\tfor i := range v {\n\t\tv[i] = %s\n\t}\n\treturn v\n}\n\n", typ, n, expr)
+
+	return "[]" + typ, fn + "(r)", nil
+}
+
+// choice generates a switch between the options. Every option must produce
+// the same type, except for null literals which turn the type into a pointer.
+func (g *generator) choice(c sham.Choice, name string) (string, string, error) {
+	if len(c.Options) == 0 {
+		return "interface{}", "nil", nil
+	}
+
+	typ := ""
+	nullable := false
+	exprs := make([]string, len(c.Options))
+	for i, o := range c.Options {
+		if l, ok := o.(sham.Literal); ok && l.Value == nil {
+			nullable = true
+			continue
+		}
+
+		t, expr, err := g.expr(o, name)
+		if err != nil {
+			return "", "", err
+		}
+
+		if typ == "" {
+			typ = t
+		} else if t != typ {
+			return "", "", fmt.Errorf("choice between different types %s and %s", typ, t)
+		}
+		exprs[i] = expr
+	}
+
+	if typ == "" {
+		return "interface{}", "nil", nil
+	}
+
+	rtyp := typ
+	if nullable {
+		rtyp = "*" + typ
+	}
+
+	fn := g.declare("newRandom" + name + "Choice")
+	fmt.Fprintf(&g.funcs, "func %s(r *rand.Rand) %s {\n\tswitch r.Intn(%d) {\n", fn, rtyp, len(c.Options))
+	for i, expr := range exprs {
+		if i == len(exprs)-1 {
+			g.funcs.WriteString("\tdefault:\n")
+		} else {
+			fmt.Fprintf(&g.funcs, "\tcase %d:\n", i)
+		}
+
+		switch {
+		case expr == "":
+			g.funcs.WriteString("\t\treturn nil\n")
+		case nullable:
+			fmt.Fprintf(&g.funcs, "\t\tv := %s\n\t\treturn &v\n", expr)
+		default:
+			fmt.Fprintf(&g.funcs, "\t\treturn %s\n", expr)
+		}
+	}
+	g.funcs.WriteString("\t}\n}\n\n")
+
+	return rtyp, fn + "(r)", nil
+}
+
+func (g *generator) formattedString(f sham.FormattedString) (string, string, error) {
+	names := f.ParamNames()
+	if len(names) == 0 {
+		return "string", strconv.Quote(f.Raw), nil
+	}
+
+	args := make([]string, len(names))
+	for i, name := range names {
+		t, err := g.terminal(name)
+		if err != nil {
+			return "", "", err
+		}
+		args[i] = t.fn + "(r)"
+	}
+
+	g.imports["fmt"] = true
+	return "string", fmt.Sprintf("fmt.Sprintf(%s, %s)", strconv.Quote(f.Format), strings.Join(args, ", ")), nil
+}
+
+func literal(v interface{}) (string, string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "interface{}", "nil", nil
+	case string:
+		return "string", strconv.Quote(v), nil
+	case int:
+		return "int", strconv.Itoa(v), nil
+	case float64:
+		return "float64", strconv.FormatFloat(v, 'g', -1, 64), nil
+	case bool:
+		return "bool", strconv.FormatBool(v), nil
+	}
+
+	return "", "", fmt.Errorf("unsupported literal %v", v)
+}
+
+// declare reserves a package level identifier based on name, appending a
+// number if the name is already taken.
+func (g *generator) declare(name string) string {
+	name = uniqueName(g.names, name)
+	g.names[name] = true
+	return name
+}
+
+func uniqueName(taken map[string]bool, name string) string {
+	if !taken[name] {
+		return name
+	}
+
+	for i := 2; ; i++ {
+		if n := name + strconv.Itoa(i); !taken[n] {
+			return n
+		}
+	}
+}
+
+// exportedName converts an object key into an exported Go identifier. The key
+// is split into words on any character that is not a letter or digit, and on
+// lowercase to uppercase transitions.
+func exportedName(key string) string {
+	words := make([]string, 0)
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = nil
+		}
+	}
+
+	prev := rune(0)
+	for _, r := range key {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && unicode.IsLower(prev):
+			flush()
+			word = append(word, r)
+		default:
+			word = append(word, r)
+		}
+		prev = r
+	}
+	flush()
+
+	var sb strings.Builder
+	for _, w := range words {
+		if upper := strings.ToUpper(w); commonInitialisms[upper] {
+			sb.WriteString(upper)
+			continue
+		}
+
+		rs := []rune(w)
+		sb.WriteRune(unicode.ToUpper(rs[0]))
+		sb.WriteString(string(rs[1:]))
+	}
+
+	name := sb.String()
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
+
+// singular derives the name of an array's elements from the array's name.
+func singular(name string) string {
+	if strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss") && len(name) > 1 {
+		return name[:len(name)-1]
+	}
+	return name + "Item"
+}
+
+func lowerFirst(s string) string {
+	rs := []rune(s)
+	rs[0] = unicode.ToLower(rs[0])
+	return string(rs)
+}
+
+func isExported(s string) bool {
+	return s != "" && unicode.IsUpper([]rune(s)[0])
+}
+
+func isIdentifier(s string) bool {
+	if !isExported(s) {
+		return false
+	}
+
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,187 @@
+package gogen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/mattmeyers/sham"
+)
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		opts    Options
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "Nested objects and arrays",
+			schema: `{"user_id": (1,9), "friends": [(1,5), {"name": name, "born": timestamp}]}`,
+			opts:   Options{Package: "fixtures", Type: "User"},
+			want: []string{
+				"package fixtures",
+				"type User struct {\n\tUserID  int          `json:\"user_id\"`\n\tFriends []UserFriend `json:\"friends\"`\n}",
+				"type UserFriend struct {\n\tName string    `json:\"name\"`\n\tBorn time.Time `json:\"born\"`\n}",
+				"func NewRandomUser(r *rand.Rand) User {",
+				"v := make([]UserFriend, r.Intn(5)+1)",
+			},
+		},
+		{
+			name:   "Optional keys and nullable choices",
+			schema: `{"a"?: (1,2), "b": /[a-z]+/ | null}`,
+			want: []string{
+				"A *int",
+				"`json:\"a,omitempty\"`",
+				"B *string",
+				`dataBRegex = sham.MustRegex("[a-z]+")`,
+			},
+		},
+		{
+			name:   "Non-object root",
+			schema: "`hello {firstName}`",
+			opts:   Options{Type: "Greeting"},
+			want: []string{
+				"type Greeting string",
+				`return Greeting(fmt.Sprintf("hello %v", gen.FirstNameRand(r)))`,
+			},
+		},
+		{
+			name:   "Generator arguments",
+			schema: `{"bio": paragraph(2), "tags": words(1, 3), "email": email("example.com"), "price": price(5, 49.99), "ratio": float(0.5, 2)}`,
+			want: []string{
+				"v.Bio = gen.ParagraphOfLength(r, 2)",
+				"v.Tags = gen.WordsOfLength(r, r.Intn(3)+1)",
+				`v.Email = gen.EmailAt(r, "example.com")`,
+				"v.Price = gen.PriceBetween(r, 5, 49.99)",
+				"v.Ratio = gen.FloatBetween(r, 0.5, 2)",
+			},
+		},
+		{
+			name:   "Locale",
+			schema: `@locale("de_DE") {"name": name, "email": email}`,
+			want: []string{
+				`locale = gen.MustLookupLocale("de_DE")`,
+				"v.Name = locale.Name(r)",
+				"v.Email = gen.Email(r)",
+			},
+		},
+		{
+			name:    "Unsupported generator arguments",
+			schema:  `ipv4("10.0.0.0/8")`,
+			wantErr: true,
+		},
+		{
+			name:    "Choice between types",
+			schema:  `(1,2) | "a"`,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid type name",
+			schema:  `1`,
+			opts:    Options{Type: "lower"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := sham.NewDefaultParser([]byte(tt.schema)).Parse()
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			got, err := Generate(s, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Generate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if err := typeCheck(got); err != nil {
+				t.Errorf("Generate() produced invalid Go: %v\n%s", err, got)
+			}
+			for _, w := range tt.want {
+				if !strings.Contains(string(got), w) {
+					t.Errorf("Generate() = %s, want it to contain %s", got, w)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerate_AllTerminals(t *testing.T) {
+	names := make([]string, 0, len(terminals))
+	for name := range terminals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("{")
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%q: %s", name, name)
+	}
+	b.WriteString("}")
+
+	for _, prefix := range []string{"", `@locale("fr_FR") `} {
+		s, err := sham.NewDefaultParser([]byte(prefix + b.String())).Parse()
+		if err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+
+		got, err := Generate(s, Options{})
+		if err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		if err := typeCheck(got); err != nil {
+			t.Errorf("Generate() produced invalid Go: %v\n%s", err, got)
+		}
+	}
+}
+
+// typeCheck parses and type-checks a generated file, importing the gen
+// package from source so the checks follow the code in this tree.
+func typeCheck(src []byte) error {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "main.go", src, 0)
+	if err != nil {
+		return err
+	}
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err = conf.Check(f.Name.Name, fset, []*ast.File{f}, nil)
+	return err
+}
+
+func TestExportedName(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"name", "Name"},
+		{"first_name", "FirstName"},
+		{"userId", "UserID"},
+		{"profile-url", "ProfileURL"},
+		{"2fa", "X2fa"},
+		{"", "X"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := exportedName(tt.key); got != tt.want {
+				t.Errorf("exportedName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
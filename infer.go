@@ -0,0 +1,510 @@
+package sham
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// maxEnumValues is the largest number of distinct strings that will be
+	// summarized as an alternation of the observed values.
+	maxEnumValues = 5
+	// maxInferredRepeat is the largest repetition count accepted by Go's
+	// regular expression parser.
+	maxInferredRepeat = 1000
+)
+
+// Infer derives a Sham schema from one or more sample JSON documents. Every
+// sample contributes to a single schema describing all of them:
+//
+//   - objects become Object nodes with keys in the order they were first seen
+//   - arrays receive a Range spanning the observed lengths, and their elements
+//     are merged into a single inner node
+//   - integers become a Range spanning the observed minimum and maximum
+//   - strings are matched against the default terminal generators (names,
+//     timestamps, phone numbers, emails, URLs, IPv4 addresses and UUIDs) or
+//     are summarized as a regular expression
+//   - booleans become the boolean generator if both values were observed
+//
+// Values that cannot be generated, such as floats, are kept as literals using
+// the first observed value. If a field holds different types across samples,
+// the most frequently observed type is used. The Sham language has no escape
+// sequences, so strings holding a double quote become regular expressions, and
+// samples with such keys are rejected.
+func Infer(samples ...[]byte) (Schema, error) {
+	if len(samples) == 0 {
+		return Schema{}, errors.New("no samples provided")
+	}
+
+	var root shape
+	for i, s := range samples {
+		v, err := decodeOrdered(json.NewDecoder(bytes.NewReader(s)))
+		if err != nil {
+			return Schema{}, fmt.Errorf("sample %d: %w", i+1, err)
+		}
+		if err := checkKeys(v); err != nil {
+			return Schema{}, fmt.Errorf("sample %d: %w", i+1, err)
+		}
+		root.observe(v)
+	}
+
+	return Schema{Root: root.node()}, nil
+}
+
+// checkKeys returns an error if an object within v has a key that cannot be
+// written in a schema.
+func checkKeys(v interface{}) error {
+	switch v := v.(type) {
+	case *OrderedMap:
+		for _, k := range v.Keys {
+			if !isQuotable(k) {
+				return fmt.Errorf("key %q cannot be written in a schema", k)
+			}
+			if err := checkKeys(v.Values[k]); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, e := range v {
+			if err := checkKeys(e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// decodeOrdered decodes a single JSON value while preserving the key order of
+// objects. Objects are decoded into an OrderedMap and numbers into json.Number.
+func decodeOrdered(dec *json.Decoder) (interface{}, error) {
+	dec.UseNumber()
+
+	t, err := dec.Token()
+	if err == io.EOF {
+		return nil, errors.New("empty input")
+	} else if err != nil {
+		return nil, err
+	}
+
+	return decodeOrderedToken(dec, t)
+}
+
+func decodeOrderedToken(dec *json.Decoder, t json.Token) (interface{}, error) {
+	switch t {
+	case json.Delim('{'):
+		m := NewOrderedMap()
+		for dec.More() {
+			k, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			t, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			v, err := decodeOrderedToken(dec, t)
+			if err != nil {
+				return nil, err
+			}
+			m.Set(k.(string), v)
+		}
+		_, err := dec.Token()
+		return m, err
+	case json.Delim('['):
+		arr := make([]interface{}, 0)
+		for dec.More() {
+			t, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			v, err := decodeOrderedToken(dec, t)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, v)
+		}
+		_, err := dec.Token()
+		return arr, err
+	}
+
+	return t, nil
+}
+
+// shape accumulates the observations made at a single position in the sample
+// documents.
+type shape struct {
+	nulls int
+
+	trues  int
+	falses int
+
+	ints    int
+	intMin  int
+	intMax  int
+	floats  int
+	float   float64
+	strings []string
+
+	arrays int
+	lenMin int
+	lenMax int
+	elem   *shape
+
+	objects int
+	keys    []string
+	fields  map[string]*shape
+}
+
+func (s *shape) observe(v interface{}) {
+	switch v := v.(type) {
+	case nil:
+		s.nulls++
+	case bool:
+		if v {
+			s.trues++
+		} else {
+			s.falses++
+		}
+	case json.Number:
+		s.observeNumber(v)
+	case string:
+		s.strings = append(s.strings, v)
+	case []interface{}:
+		s.observeArray(v)
+	case *OrderedMap:
+		s.observeObject(v)
+	}
+}
+
+func (s *shape) observeNumber(n json.Number) {
+	if i, err := n.Int64(); err == nil {
+		if s.ints == 0 || int(i) < s.intMin {
+			s.intMin = int(i)
+		}
+		if s.ints == 0 || int(i) > s.intMax {
+			s.intMax = int(i)
+		}
+		s.ints++
+		return
+	}
+
+	if f, err := n.Float64(); err == nil {
+		if s.floats == 0 {
+			s.float = f
+		}
+		s.floats++
+	}
+}
+
+func (s *shape) observeArray(arr []interface{}) {
+	if s.arrays == 0 || len(arr) < s.lenMin {
+		s.lenMin = len(arr)
+	}
+	if s.arrays == 0 || len(arr) > s.lenMax {
+		s.lenMax = len(arr)
+	}
+	s.arrays++
+
+	for _, v := range arr {
+		if s.elem == nil {
+			s.elem = &shape{}
+		}
+		s.elem.observe(v)
+	}
+}
+
+func (s *shape) observeObject(m *OrderedMap) {
+	if s.fields == nil {
+		s.fields = make(map[string]*shape)
+	}
+	s.objects++
+
+	for _, k := range m.Keys {
+		f, ok := s.fields[k]
+		if !ok {
+			f = &shape{}
+			s.fields[k] = f
+			s.keys = append(s.keys, k)
+		}
+		f.observe(m.Values[k])
+	}
+}
+
+// node converts the accumulated observations into an AST node. The most
+// frequently observed type wins, with ties broken in favor of the more
+// structured type.
+func (s *shape) node() Node {
+	kinds := []struct {
+		count int
+		node  func() Node
+	}{
+		{s.objects, s.objectNode},
+		{s.arrays, s.arrayNode},
+		{len(s.strings), s.stringNode},
+		{s.ints + s.floats, s.numberNode},
+		{s.trues + s.falses, s.boolNode},
+	}
+
+	best := -1
+	for i, k := range kinds {
+		if k.count > 0 && (best == -1 || k.count > kinds[best].count) {
+			best = i
+		}
+	}
+
+	if best == -1 {
+		return Literal{Value: nil}
+	}
+	return kinds[best].node()
+}
+
+func (s *shape) objectNode() Node {
+	obj := Object{}
+	for _, k := range s.keys {
+		obj.AppendPair(k, s.fields[k].node())
+	}
+	return obj
+}
+
+func (s *shape) arrayNode() Node {
+	if s.elem == nil {
+		return Array{}
+	}
+
+	return Array{
+		Range: &Range{Min: s.lenMin, Max: s.lenMax},
+		Inner: s.elem.node(),
+	}
+}
+
+// numberNode produces a range of integers unless a float was observed, in
+// which case the first observed float is used as a literal.
+func (s *shape) numberNode() Node {
+	if s.floats > 0 {
+		return Literal{Value: s.float}
+	}
+	return Range{Min: s.intMin, Max: s.intMax}
+}
+
+func (s *shape) boolNode() Node {
+	if s.trues == 0 {
+		return Literal{Value: false}
+	} else if s.falses == 0 {
+		return Literal{Value: true}
+	}
+	return newDefaultTerminalGenerator("boolean")
+}
+
+var (
+	inferNameRegex  = regexp.MustCompile(`^[A-Z][a-z]+ [A-Z][a-z]+$`)
+	inferPhoneRegex = regexp.MustCompile(`^\d{3}-\d{3}-\d{4}$`)
+	inferEmailRegex = regexp.MustCompile(`^[^@\s]+@[a-zA-Z0-9-]+(\.[a-zA-Z0-9-]+)+$`)
+	inferURLRegex   = regexp.MustCompile(`^https?://[^\s/]+(/\S*)?$`)
+	inferUUIDRegex  = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+func (s *shape) stringNode() Node {
+	switch {
+	case allMatch(s.strings, isTimestamp):
+		return newDefaultTerminalGenerator("timestamp")
+	case allMatch(s.strings, inferPhoneRegex.MatchString):
+		return newDefaultTerminalGenerator("phoneNumber")
+	case allMatch(s.strings, inferNameRegex.MatchString):
+		return newDefaultTerminalGenerator("name")
+	case allMatch(s.strings, inferEmailRegex.MatchString):
+		return newDefaultTerminalGenerator("email")
+	case allMatch(s.strings, inferURLRegex.MatchString):
+		return newDefaultTerminalGenerator("url")
+	case allMatch(s.strings, isIPv4):
+		return newDefaultTerminalGenerator("ipv4")
+	case allMatch(s.strings, inferUUIDRegex.MatchString):
+		return newDefaultTerminalGenerator("uuid")
+	}
+
+	distinct := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, str := range s.strings {
+		if !seen[str] {
+			seen[str] = true
+			distinct = append(distinct, str)
+		}
+	}
+
+	if len(distinct) == 1 {
+		if !isQuotable(distinct[0]) {
+			return MustRegex(quoteRegex(distinct[0]))
+		}
+		return Literal{Value: distinct[0]}
+	}
+
+	// A small set of values that repeat across samples is most likely an
+	// enumeration, so the exact values are preserved.
+	if len(distinct) <= maxEnumValues && len(s.strings) >= 2*len(distinct) {
+		alts := make([]string, len(distinct))
+		for i, str := range distinct {
+			alts[i] = quoteRegex(str)
+		}
+		return MustRegex(strings.Join(alts, "|"))
+	}
+
+	return MustRegex(summarizeStrings(s.strings))
+}
+
+func allMatch(vals []string, f func(string) bool) bool {
+	for _, v := range vals {
+		if !f(v) {
+			return false
+		}
+	}
+	return len(vals) > 0
+}
+
+func isIPv4(s string) bool {
+	ip := net.ParseIP(s)
+	return ip != nil && ip.To4() != nil && strings.Contains(s, ".")
+}
+
+func isTimestamp(s string) bool {
+	_, err := time.Parse(time.RFC3339, s)
+	return err == nil
+}
+
+func newDefaultTerminalGenerator(name string) TerminalGenerator {
+	return TerminalGenerator{Name: name, fn: TerminalGenerators[name]}
+}
+
+// quoteRegex escapes all regular expression metacharacters in s, including the
+// forward slash that delimits regular expressions in the Sham language.
+// Backslashes are written as \x5c rather than \\, since a trailing \\ would
+// escape the closing slash.
+func quoteRegex(s string) string {
+	s = strings.ReplaceAll(regexp.QuoteMeta(s), `\\`, `\x5c`)
+	return strings.ReplaceAll(s, "/", `\/`)
+}
+
+// charRun is a run of characters belonging to the same class. Characters that
+// do not belong to a class are kept as literals with a class of "".
+type charRun struct {
+	class   string
+	literal rune
+	min     int
+	max     int
+}
+
+func classOf(r rune) string {
+	switch {
+	case 'a' <= r && r <= 'z':
+		return "[a-z]"
+	case 'A' <= r && r <= 'Z':
+		return "[A-Z]"
+	case '0' <= r && r <= '9':
+		return `\d`
+	}
+	return ""
+}
+
+func charRuns(s string) []charRun {
+	runs := make([]charRun, 0)
+	for _, r := range s {
+		c := classOf(r)
+		if n := len(runs); n > 0 && c != "" && runs[n-1].class == c {
+			runs[n-1].min++
+			runs[n-1].max++
+			continue
+		}
+		runs = append(runs, charRun{class: c, literal: r, min: 1, max: 1})
+	}
+	return runs
+}
+
+// summarizeStrings produces a regular expression that generates strings
+// resembling the provided values. If every value is made of the same sequence
+// of character classes and literals, then the sequence is preserved with
+// repetition counts spanning the observed run lengths. Otherwise a single
+// character class spanning the observed string lengths is used.
+func summarizeStrings(vals []string) string {
+	runs := charRuns(vals[0])
+	for _, v := range vals[1:] {
+		other := charRuns(v)
+		if !sameRuns(runs, other) {
+			return summarizeLengths(vals)
+		}
+
+		for i := range runs {
+			if other[i].min < runs[i].min {
+				runs[i].min = other[i].min
+			}
+			if other[i].max > runs[i].max {
+				runs[i].max = other[i].max
+			}
+		}
+	}
+
+	var sb strings.Builder
+	for _, r := range runs {
+		r.min, r.max = capRepeat(r.min), capRepeat(r.max)
+		if r.class == "" {
+			sb.WriteString(quoteRegex(string(r.literal)))
+			continue
+		}
+
+		sb.WriteString(r.class)
+		if r.min == r.max {
+			if r.min > 1 {
+				fmt.Fprintf(&sb, "{%d}", r.min)
+			}
+		} else {
+			fmt.Fprintf(&sb, "{%d,%d}", r.min, r.max)
+		}
+	}
+	return sb.String()
+}
+
+func sameRuns(a, b []charRun) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i].class != b[i].class || (a[i].class == "" && a[i].literal != b[i].literal) {
+			return false
+		}
+	}
+	return true
+}
+
+func summarizeLengths(vals []string) string {
+	min, max := -1, 0
+	for _, v := range vals {
+		n := len([]rune(v))
+		if min == -1 || n < min {
+			min = n
+		}
+		if n > max {
+			max = n
+		}
+	}
+
+	min, max = capRepeat(min), capRepeat(max)
+	if min == max {
+		return fmt.Sprintf("[a-z]{%d}", min)
+	}
+	return fmt.Sprintf("[a-z]{%d,%d}", min, max)
+}
+
+func capRepeat(n int) int {
+	if n > maxInferredRepeat {
+		return maxInferredRepeat
+	}
+	return n
+}
@@ -0,0 +1,104 @@
+package sham
+
+import "testing"
+
+func TestInfer(t *testing.T) {
+	tests := []struct {
+		name    string
+		samples []string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "No samples",
+			samples: nil,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid JSON",
+			samples: []string{`{"a": }`},
+			wantErr: true,
+		},
+		{
+			name:    "Integer range",
+			samples: []string{`5`, `-3`, `12`},
+			want:    `(-3,12)`,
+		},
+		{
+			name:    "Terminal generators",
+			samples: []string{`["John Doe", "Jane Roe"]`, `["Bob Smith"]`},
+			want:    `[(1,2), name]`,
+		},
+		{
+			name:    "Enumerated strings",
+			samples: []string{`["open", "closed"]`, `["closed", "open"]`},
+			want:    `[(2), /open|closed/]`,
+		},
+		{
+			name:    "Summarized strings",
+			samples: []string{`"AB-1234"`, `"XYZ-12"`},
+			want:    `/[A-Z]{2,3}-\d{2,4}/`,
+		},
+		{
+			name:    "String holding quotes",
+			samples: []string{`{"msg": "say \"hi\""}`},
+			want:    "{\n    \"msg\": /say \"hi\"/\n}",
+		},
+		{
+			name:    "Trailing backslashes",
+			samples: []string{`["a\\", "b\\"]`, `["b\\", "a\\"]`},
+			want:    `[(2), /a\x5c|b\x5c/]`,
+		},
+		{
+			name:    "Summarized backslashes",
+			samples: []string{`"a\\"`, `"bc\\"`},
+			want:    `/[a-z]{1,2}\x5c/`,
+		},
+		{
+			name:    "Key holding quotes",
+			samples: []string{`{"a\"b": 1}`},
+			wantErr: true,
+		},
+		{
+			name: "Merged objects",
+			samples: []string{
+				`{"id": 1, "active": true, "score": 1.5, "meta": {}}`,
+				`{"id": 9, "active": false, "score": 2, "extra": null}`,
+			},
+			want: `{
+    "id": (1,9),
+    "active": boolean,
+    "score": 1.5,
+    "meta": {},
+    "extra": null
+}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			samples := make([][]byte, len(tt.samples))
+			for i, s := range tt.samples {
+				samples[i] = []byte(s)
+			}
+
+			got, err := Infer(samples...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Infer() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.String() != tt.want {
+				t.Errorf("Infer() = %s, want %s", got, tt.want)
+			}
+
+			reparsed, err := NewDefaultParser([]byte(got.String())).Parse()
+			if err != nil {
+				t.Errorf("Infer() produced an unparsable schema: %v", err)
+			} else if reparsed.String() != got.String() {
+				t.Errorf("reparsed schema = %s, want %s", reparsed, got)
+			}
+		})
+	}
+}
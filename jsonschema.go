@@ -0,0 +1,660 @@
+package sham
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	// defaultMaxItems is the number of elements added to minItems when a JSON
+	// Schema array does not define maxItems.
+	defaultMaxItems = 5
+	// defaultNumberSpan is the width of the range used when a JSON Schema
+	// number is missing one or both of its bounds.
+	defaultNumberSpan = 100
+	// defaultMinLength and defaultMaxLength bound the length of strings that
+	// do not define a pattern, format, or length.
+	defaultMinLength = 5
+	defaultMaxLength = 10
+)
+
+// formatGenerators maps JSON Schema string formats to the nodes used to
+// generate them. Formats without an entry fall back to the string's length
+// constraints.
+var formatGenerators = map[string]func() Node{
+	"date-time": func() Node { return newDefaultTerminalGenerator("timestamp") },
+	"date":      func() Node { return MustRegex(`(19[7-9]\d|20[0-2]\d)-(0[1-9]|1[0-2])-(0[1-9]|1\d|2[0-8])`) },
+	"time":      func() Node { return MustRegex(`([01]\d|2[0-3]):[0-5]\d:[0-5]\dZ`) },
+	"email":     func() Node { return newDefaultTerminalGenerator("email") },
+	"hostname":  func() Node { return newDefaultTerminalGenerator("domain") },
+	"uri":       func() Node { return newDefaultTerminalGenerator("url") },
+	"ipv4":      func() Node { return newDefaultTerminalGenerator("ipv4") },
+	"ipv6":      func() Node { return newDefaultTerminalGenerator("ipv6") },
+	"uuid":      func() Node { return newDefaultTerminalGenerator("uuid") },
+}
+
+// ImportJSONSchema converts a JSON Schema document into a Sham schema. Draft 7
+// and 2020-12 documents are supported, although only the keywords that affect
+// the shape of the data are used:
+//
+//   - type, including lists of types, selects the kind of node
+//   - properties become object pairs, and properties missing from required
+//     become optional pairs
+//   - enum and const become choices of literals
+//   - minimum and maximum become a Range
+//   - pattern becomes a Regex
+//   - minItems and maxItems become the range of an array
+//   - the date-time, email, hostname, uri, ipv4, ipv6 and uuid formats become
+//     terminal generators or regular expressions producing valid values
+//   - oneOf and anyOf become choices, and allOf merges its subschemas
+//   - $ref is resolved against the document and inlined
+//
+// Recursive references cannot be represented by a Sham schema and result in
+// an error.
+func ImportJSONSchema(d []byte) (Schema, error) {
+	v, err := decodeOrdered(json.NewDecoder(bytes.NewReader(d)))
+	if err != nil {
+		return Schema{}, err
+	}
+
+	c := &jsonSchemaConverter{root: v}
+	n, err := c.convert(v)
+	if err != nil {
+		return Schema{}, err
+	}
+
+	return Schema{Root: n}, nil
+}
+
+// jsonSchemaConverter maintains the state needed to convert a JSON Schema
+// document into an AST. The root document is retained to resolve references,
+// and the references currently being resolved are tracked to detect cycles.
+// When openAPI is set, the OpenAPI 3 extensions nullable and example are
+// honored as well.
+type jsonSchemaConverter struct {
+	root    interface{}
+	refs    []string
+	openAPI bool
+}
+
+func (c *jsonSchemaConverter) convert(v interface{}) (Node, error) {
+	switch v := v.(type) {
+	case bool:
+		if !v {
+			return nil, errors.New("the false schema cannot generate data")
+		}
+		return Literal{Value: nil}, nil
+	case *OrderedMap:
+		return c.convertObject(v)
+	}
+
+	return nil, fmt.Errorf("expected a schema object, got %T", v)
+}
+
+func (c *jsonSchemaConverter) convertObject(s *OrderedMap) (Node, error) {
+	n, err := c.convertSchema(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if nullable, _ := s.Values["nullable"].(bool); c.openAPI && nullable {
+		return Choice{Options: []Node{n, Literal{Value: nil}}}, nil
+	}
+	return n, nil
+}
+
+func (c *jsonSchemaConverter) convertSchema(s *OrderedMap) (Node, error) {
+	if ref, ok := s.Values["$ref"].(string); ok {
+		return c.convertRef(ref)
+	}
+
+	if all, ok := s.Values["allOf"].([]interface{}); ok {
+		merged, err := c.mergeAllOf(s, all)
+		if err != nil {
+			return nil, err
+		}
+		return c.convertSchema(merged)
+	}
+
+	if v, ok := s.Values["const"]; ok {
+		n, err := literalNode(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid const: %w", err)
+		}
+		return n, nil
+	}
+
+	if enum, ok := s.Values["enum"].([]interface{}); ok {
+		if len(enum) == 0 {
+			return nil, errors.New("enum has no values")
+		}
+		ns, err := literalNodes(enum)
+		if err != nil {
+			return nil, fmt.Errorf("invalid enum: %w", err)
+		}
+		return choiceOf(ns), nil
+	}
+
+	for _, k := range []string{"oneOf", "anyOf"} {
+		if subs, ok := s.Values[k].([]interface{}); ok {
+			if len(subs) == 0 {
+				return nil, fmt.Errorf("%s has no schemas", k)
+			}
+			return c.convertChoice(subs)
+		}
+	}
+
+	switch t := s.Values["type"].(type) {
+	case string:
+		return c.convertType(s, t)
+	case []interface{}:
+		opts := make([]Node, 0, len(t))
+		for _, typ := range t {
+			name, ok := typ.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid type %v", typ)
+			}
+
+			n, err := c.convertType(s, name)
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, n)
+		}
+		return choiceOf(opts), nil
+	}
+
+	return c.convertType(s, impliedType(s))
+}
+
+func (c *jsonSchemaConverter) convertType(s *OrderedMap, t string) (Node, error) {
+	if n, ok := c.convertExample(s, t); ok {
+		return n, nil
+	}
+
+	switch t {
+	case "object":
+		return c.convertProperties(s)
+	case "array":
+		return c.convertArray(s)
+	case "string":
+		return convertString(s)
+	case "integer", "number":
+		return convertNumber(s, t == "integer")
+	case "boolean":
+		return newDefaultTerminalGenerator("boolean"), nil
+	case "null":
+		return Literal{Value: nil}, nil
+	}
+
+	return nil, fmt.Errorf("unknown type %q", t)
+}
+
+// convertExample uses the example values of an OpenAPI scalar schema in place
+// of generated values. Examples are only used when the schema does not already
+// constrain its values with a pattern or format, and can be written in a
+// schema.
+func (c *jsonSchemaConverter) convertExample(s *OrderedMap, t string) (Node, bool) {
+	if !c.openAPI || t == "object" || t == "array" || t == "null" {
+		return nil, false
+	}
+
+	for _, k := range []string{"pattern", "format"} {
+		if _, ok := s.Values[k]; ok {
+			return nil, false
+		}
+	}
+
+	if v, ok := s.Values["example"]; ok {
+		n, err := literalNode(v)
+		return n, err == nil
+	} else if vs, ok := s.Values["examples"].([]interface{}); ok && len(vs) > 0 {
+		ns, err := literalNodes(vs)
+		if err != nil {
+			return nil, false
+		}
+		return choiceOf(ns), true
+	}
+
+	return nil, false
+}
+
+// impliedType determines the type of a schema without a type keyword from the
+// other keywords present in the schema.
+func impliedType(s *OrderedMap) string {
+	has := func(keys ...string) bool {
+		for _, k := range keys {
+			if _, ok := s.Values[k]; ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case has("properties", "required", "additionalProperties"):
+		return "object"
+	case has("items", "prefixItems", "minItems", "maxItems"):
+		return "array"
+	case has("pattern", "format", "minLength", "maxLength"):
+		return "string"
+	case has("minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf"):
+		return "number"
+	}
+
+	return "null"
+}
+
+func (c *jsonSchemaConverter) convertRef(ref string) (Node, error) {
+	for _, r := range c.refs {
+		if r == ref {
+			return nil, fmt.Errorf("recursive reference %q", ref)
+		}
+	}
+
+	target, err := resolvePointer(c.root, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	c.refs = append(c.refs, ref)
+	defer func() { c.refs = c.refs[:len(c.refs)-1] }()
+
+	return c.convert(target)
+}
+
+// resolvePointer resolves a local reference, such as "#/$defs/User", against
+// the root document. References to other documents are not supported.
+func resolvePointer(root interface{}, ref string) (interface{}, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("unsupported reference %q: only local references are supported", ref)
+	}
+
+	v := root
+	for _, tok := range strings.Split(strings.TrimPrefix(ref[1:], "/"), "/") {
+		if tok == "" {
+			continue
+		}
+		tok = strings.NewReplacer("~1", "/", "~0", "~").Replace(tok)
+
+		switch cur := v.(type) {
+		case *OrderedMap:
+			next, ok := cur.Values[tok]
+			if !ok {
+				return nil, fmt.Errorf("unresolvable reference %q", ref)
+			}
+			v = next
+		case []interface{}:
+			i, err := strconv.Atoi(tok)
+			if err != nil || i < 0 || i >= len(cur) {
+				return nil, fmt.Errorf("unresolvable reference %q", ref)
+			}
+			v = cur[i]
+		default:
+			return nil, fmt.Errorf("unresolvable reference %q", ref)
+		}
+	}
+
+	return v, nil
+}
+
+// mergeAllOf combines the subschemas of an allOf with the schema containing
+// it. Properties and required keys are combined, while every other keyword
+// keeps its first definition.
+func (c *jsonSchemaConverter) mergeAllOf(s *OrderedMap, all []interface{}) (*OrderedMap, error) {
+	merged := NewOrderedMap()
+	props := NewOrderedMap()
+	required := make([]interface{}, 0)
+
+	add := func(sub *OrderedMap) {
+		for _, k := range sub.Keys {
+			switch k {
+			case "allOf":
+			case "properties":
+				if p, ok := sub.Values[k].(*OrderedMap); ok {
+					for _, pk := range p.Keys {
+						props.Set(pk, p.Values[pk])
+					}
+				}
+			case "required":
+				if r, ok := sub.Values[k].([]interface{}); ok {
+					required = append(required, r...)
+				}
+			default:
+				if _, ok := merged.Values[k]; !ok {
+					merged.Set(k, sub.Values[k])
+				}
+			}
+		}
+	}
+
+	add(s)
+	for _, v := range all {
+		sub, err := c.resolveSchema(v)
+		if err != nil {
+			return nil, err
+		}
+		add(sub)
+	}
+
+	if len(props.Keys) > 0 {
+		merged.Set("properties", props)
+	}
+	if len(required) > 0 {
+		merged.Set("required", required)
+	}
+
+	return merged, nil
+}
+
+// resolveSchema follows references until a schema object is reached. Nested
+// allOf keywords are merged so the result can be combined with its siblings.
+func (c *jsonSchemaConverter) resolveSchema(v interface{}) (*OrderedMap, error) {
+	seen := make(map[string]bool)
+	for {
+		s, ok := v.(*OrderedMap)
+		if !ok {
+			return nil, fmt.Errorf("expected a schema object in allOf, got %T", v)
+		}
+
+		ref, ok := s.Values["$ref"].(string)
+		if !ok {
+			if all, ok := s.Values["allOf"].([]interface{}); ok {
+				return c.mergeAllOf(s, all)
+			}
+			return s, nil
+		} else if seen[ref] {
+			return nil, fmt.Errorf("recursive reference %q", ref)
+		}
+		seen[ref] = true
+
+		var err error
+		v, err = resolvePointer(c.root, ref)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *jsonSchemaConverter) convertChoice(subs []interface{}) (Node, error) {
+	opts := make([]Node, len(subs))
+	for i, sub := range subs {
+		n, err := c.convert(sub)
+		if err != nil {
+			return nil, err
+		}
+		opts[i] = n
+	}
+	return choiceOf(opts), nil
+}
+
+func (c *jsonSchemaConverter) convertProperties(s *OrderedMap) (Node, error) {
+	required := make(map[string]bool)
+	if r, ok := s.Values["required"].([]interface{}); ok {
+		for _, k := range r {
+			if k, ok := k.(string); ok {
+				required[k] = true
+			}
+		}
+	}
+
+	obj := Object{}
+	props, _ := s.Values["properties"].(*OrderedMap)
+	if props == nil {
+		return obj, nil
+	}
+
+	for _, k := range props.Keys {
+		if !isQuotable(k) {
+			return nil, fmt.Errorf("property %q cannot be written in a schema", k)
+		}
+		n, err := c.convert(props.Values[k])
+		if err != nil {
+			return nil, fmt.Errorf("property %q: %w", k, err)
+		}
+
+		if required[k] {
+			obj.AppendPair(k, n)
+		} else {
+			obj.AppendOptionalPair(k, n)
+		}
+	}
+
+	return obj, nil
+}
+
+func (c *jsonSchemaConverter) convertArray(s *OrderedMap) (Node, error) {
+	items, ok := s.Values["items"]
+	if tuple, isTuple := items.([]interface{}); isTuple {
+		// Draft 7 tuple validation. Sham arrays have a single element type,
+		// so the first item schema is used.
+		items, ok = nil, len(tuple) > 0
+		if ok {
+			items = tuple[0]
+		}
+	} else if prefix, isPrefix := s.Values["prefixItems"].([]interface{}); !ok && isPrefix && len(prefix) > 0 {
+		items, ok = prefix[0], true
+	}
+
+	if !ok {
+		return Array{}, nil
+	}
+
+	inner, err := c.convert(items)
+	if err != nil {
+		return nil, err
+	}
+
+	min, max, err := lengthBounds(s, "minItems", "maxItems")
+	if err != nil {
+		return nil, err
+	}
+	r := Range{Min: 0, Max: defaultMaxItems}
+	switch {
+	case min >= 0 && max >= 0:
+		r.Min, r.Max = min, max
+	case min >= 0:
+		r.Min, r.Max = min, min+defaultMaxItems
+	case max >= 0:
+		r.Max = max
+	}
+
+	return Array{Range: &r, Inner: inner}, nil
+}
+
+func convertString(s *OrderedMap) (Node, error) {
+	if pattern, ok := s.Values["pattern"].(string); ok {
+		r, err := NewRegex(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		return r, nil
+	}
+
+	if format, ok := s.Values["format"].(string); ok {
+		if f, ok := formatGenerators[format]; ok {
+			return f(), nil
+		}
+	}
+
+	min, max, err := lengthBounds(s, "minLength", "maxLength")
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case min < 0 && max < 0:
+		min, max = defaultMinLength, defaultMaxLength
+	case min < 0:
+		min = 0
+	case max < 0:
+		max = min + defaultMaxLength - defaultMinLength
+	}
+	min, max = capRepeat(min), capRepeat(max)
+
+	return NewRegex(fmt.Sprintf("[a-z]{%d,%d}", min, max))
+}
+
+// lengthBounds reads a pair of length keywords, such as minLength and
+// maxLength, returning -1 for a missing keyword. Negative lengths and a
+// minimum above the maximum are errors.
+func lengthBounds(s *OrderedMap, minKey, maxKey string) (min, max int, err error) {
+	min, max = intKeyword(s, minKey, -1), intKeyword(s, maxKey, -1)
+	for _, k := range []string{minKey, maxKey} {
+		if n, ok := numberKeyword(s, k); ok && n < 0 {
+			return 0, 0, fmt.Errorf("negative %s %v", k, n)
+		}
+	}
+	if min >= 0 && max >= 0 && min > max {
+		return 0, 0, fmt.Errorf("%s %d is greater than %s %d", minKey, min, maxKey, max)
+	}
+	return min, max, nil
+}
+
+// convertNumber converts an integer into a range, and a number into the float
+// terminal generator. Missing bounds are placed defaultNumberSpan away from the
+// other bound, and bounds that leave no value to generate are an error.
+func convertNumber(s *OrderedMap, integer bool) (Node, error) {
+	min, exclusiveMin, hasMin := numberBound(s, "minimum", "exclusiveMinimum", true)
+	max, exclusiveMax, hasMax := numberBound(s, "maximum", "exclusiveMaximum", false)
+
+	switch {
+	case !hasMin && !hasMax:
+		min, max = 0, defaultNumberSpan
+	case !hasMin:
+		min = max - defaultNumberSpan
+	case !hasMax:
+		max = min + defaultNumberSpan
+	}
+
+	if integer {
+		lo, hi := math.Ceil(min), math.Floor(max)
+		if exclusiveMin {
+			lo = math.Floor(min) + 1
+		}
+		if exclusiveMax {
+			hi = math.Ceil(max) - 1
+		}
+		if hi < lo {
+			return nil, fmt.Errorf("no integer lies between the bounds %v and %v", min, max)
+		}
+		return Range{Min: int(lo), Max: int(hi)}, nil
+	}
+
+	// The float generator only reaches its bounds when nothing lies between
+	// them, which satisfies inclusive bounds alone.
+	if max < min || (math.Nextafter(min, max) >= max && (exclusiveMin || exclusiveMax)) {
+		return nil, fmt.Errorf("no number lies between the bounds %v and %v", min, max)
+	}
+
+	t := newDefaultTerminalGenerator("float")
+	t.Args = []interface{}{min, max}
+	fn, err := t.fn.(ArgGenerator).WithArgs(t.Args)
+	if err != nil {
+		return nil, err
+	}
+	t.fn = fn
+	return t, nil
+}
+
+// numberBound reads the lower or upper bound of a number, keeping the tighter
+// of its inclusive and exclusive keywords.
+func numberBound(s *OrderedMap, key, exclusiveKey string, lower bool) (v float64, exclusive, ok bool) {
+	v, ok = numberKeyword(s, key)
+	if e, eok := numberKeyword(s, exclusiveKey); eok && (!ok || (lower && e >= v) || (!lower && e <= v)) {
+		return e, true, true
+	}
+	return v, false, ok
+}
+
+func numberKeyword(s *OrderedMap, k string) (float64, bool) {
+	n, ok := s.Values[k].(json.Number)
+	if !ok {
+		return 0, false
+	}
+
+	f, err := n.Float64()
+	return f, err == nil
+}
+
+func intKeyword(s *OrderedMap, k string, def int) int {
+	f, ok := numberKeyword(s, k)
+	if !ok {
+		return def
+	}
+	return int(f)
+}
+
+// literalNode converts a decoded JSON value into a node that always generates
+// the same value. Arrays are only supported when their elements are all equal,
+// since a Sham array repeats a single element.
+func literalNode(v interface{}) (Node, error) {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return Literal{Value: int(i)}, nil
+		}
+		f, _ := v.Float64()
+		return Literal{Value: f}, nil
+	case *OrderedMap:
+		obj := Object{}
+		for _, k := range v.Keys {
+			if !isQuotable(k) {
+				return nil, fmt.Errorf("key %q cannot be written in a schema", k)
+			}
+			n, err := literalNode(v.Values[k])
+			if err != nil {
+				return nil, err
+			}
+			obj.AppendPair(k, n)
+		}
+		return obj, nil
+	case []interface{}:
+		if len(v) == 0 {
+			return Array{}, nil
+		}
+		for _, e := range v[1:] {
+			if !reflect.DeepEqual(e, v[0]) {
+				return nil, errors.New("arrays with differing elements cannot be written in a schema")
+			}
+		}
+
+		inner, err := literalNode(v[0])
+		if err != nil {
+			return nil, err
+		}
+		arr := Array{Inner: inner}
+		if len(v) > 1 {
+			arr.Range = &Range{Min: len(v), Max: len(v)}
+		}
+		return arr, nil
+	}
+
+	return Literal{Value: v}, nil
+}
+
+func literalNodes(vs []interface{}) ([]Node, error) {
+	ns := make([]Node, len(vs))
+	for i, v := range vs {
+		n, err := literalNode(v)
+		if err != nil {
+			return nil, err
+		}
+		ns[i] = n
+	}
+	return ns, nil
+}
+
+// choiceOf wraps multiple options in a Choice. A single option is returned
+// as-is.
+func choiceOf(opts []Node) Node {
+	if len(opts) == 1 {
+		return opts[0]
+	}
+	return Choice{Options: opts}
+}
@@ -0,0 +1,186 @@
+package sham
+
+import "testing"
+
+func TestImportJSONSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "Integer bounds",
+			schema: `{"type": "integer", "minimum": 18, "maximum": 99}`,
+			want:   `(18,99)`,
+		},
+		{
+			name:   "Exclusive integer bounds",
+			schema: `{"type": "integer", "exclusiveMinimum": 0, "exclusiveMaximum": 10}`,
+			want:   `(1,9)`,
+		},
+		{
+			name:   "Fractional integer bounds",
+			schema: `{"type": "integer", "exclusiveMinimum": 0.5, "maximum": 9.5, "exclusiveMaximum": 9.2}`,
+			want:   `(1,9)`,
+		},
+		{
+			name:    "Integer bounds without an integer",
+			schema:  `{"type": "integer", "minimum": 0.1, "maximum": 0.9}`,
+			wantErr: true,
+		},
+		{
+			name:   "Fractional number bounds",
+			schema: `{"type": "number", "minimum": 0.1, "maximum": 0.9}`,
+			want:   `float(0.1, 0.9)`,
+		},
+		{
+			name:   "Exclusive number bounds",
+			schema: `{"type": "number", "exclusiveMinimum": 0, "exclusiveMaximum": 2.5}`,
+			want:   `float(0.0, 2.5)`,
+		},
+		{
+			name:   "Single number",
+			schema: `{"type": "number", "minimum": 1.5, "maximum": 1.5}`,
+			want:   `float(1.5, 1.5)`,
+		},
+		{
+			name:    "Exclusive number bounds without a number",
+			schema:  `{"type": "number", "minimum": 1.5, "exclusiveMaximum": 1.5}`,
+			wantErr: true,
+		},
+		{
+			name:   "Enum",
+			schema: `{"enum": ["a", 1, null]}`,
+			want:   `"a" | 1 | null`,
+		},
+		{
+			name:   "Zero const",
+			schema: `{"type": "object", "properties": {"k": {"const": 0}, "e": {"enum": [0, 1]}}}`,
+			want:   "{\n    \"k\"?: 0,\n    \"e\"?: 0 | 1\n}",
+		},
+		{
+			name:   "Array const",
+			schema: `{"enum": [[], ["x"], [2, 2, 2]]}`,
+			want:   `[] | ["x"] | [(3), 2]`,
+		},
+		{
+			name:    "Array const with differing elements",
+			schema:  `{"const": [1, 2]}`,
+			wantErr: true,
+		},
+		{
+			name:   "String const holding quotes",
+			schema: `{"const": "say \"hi\""}`,
+			want:   `/say "hi"/`,
+		},
+		{
+			name:    "Property holding quotes",
+			schema:  `{"properties": {"a\"b": {"type": "integer"}}}`,
+			wantErr: true,
+		},
+		{
+			name:    "String length range reversed",
+			schema:  `{"type": "string", "minLength": 5, "maxLength": 3}`,
+			wantErr: true,
+		},
+		{
+			name:    "Negative array length",
+			schema:  `{"type": "array", "items": {"type": "integer"}, "minItems": -1}`,
+			wantErr: true,
+		},
+		{
+			name:    "Array length range reversed",
+			schema:  `{"type": "array", "items": {"type": "integer"}, "minItems": 3, "maxItems": 1}`,
+			wantErr: true,
+		},
+		{
+			name:   "Pattern holding slashes",
+			schema: `{"type": "string", "pattern": "^https://[a-z]+/$"}`,
+			want:   `/^https:\/\/[a-z]+\/$/`,
+		},
+		{
+			name:    "Empty enum",
+			schema:  `{"type": "object", "properties": {"e": {"enum": []}}}`,
+			wantErr: true,
+		},
+		{
+			name:    "Empty oneOf",
+			schema:  `{"oneOf": []}`,
+			wantErr: true,
+		},
+		{
+			name:   "Nullable type list",
+			schema: `{"type": ["string", "null"], "format": "date-time"}`,
+			want:   `timestamp | null`,
+		},
+		{
+			name:   "Array bounds",
+			schema: `{"type": "array", "items": {"type": "string", "pattern": "[A-Z]{3}"}, "minItems": 1, "maxItems": 3}`,
+			want:   `[(1,3), /[A-Z]{3}/]`,
+		},
+		{
+			name: "Required and optional properties",
+			schema: `{
+				"type": "object",
+				"required": ["id"],
+				"properties": {"id": {"type": "integer"}, "ok": {"type": "boolean"}}
+			}`,
+			want: `{
+    "id": (0,100),
+    "ok"?: boolean
+}`,
+		},
+		{
+			name: "References and allOf",
+			schema: `{
+				"allOf": [{"$ref": "#/$defs/named"}, {"properties": {"age": {"const": 3}}, "required": ["age"]}],
+				"$defs": {"named": {"type": "object", "properties": {"name": {"const": "x"}}, "required": ["name"]}}
+			}`,
+			want: `{
+    "name": "x",
+    "age": 3
+}`,
+		},
+		{
+			name: "Recursive reference",
+			schema: `{
+				"$ref": "#/definitions/node",
+				"definitions": {"node": {"type": "object", "properties": {"next": {"$ref": "#/definitions/node"}}}}
+			}`,
+			wantErr: true,
+		},
+		{
+			name:    "Unresolvable reference",
+			schema:  `{"$ref": "#/definitions/missing"}`,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid pattern",
+			schema:  `{"type": "string", "pattern": "("}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ImportJSONSchema([]byte(tt.schema))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ImportJSONSchema() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.String() != tt.want {
+				t.Errorf("ImportJSONSchema() = %s, want %s", got, tt.want)
+			}
+
+			reparsed, err := NewDefaultParser([]byte(got.String())).Parse()
+			if err != nil {
+				t.Errorf("ImportJSONSchema() produced an unparsable schema: %v", err)
+			} else if reparsed.String() != got.String() {
+				t.Errorf("reparsed schema = %s, want %s", reparsed, got)
+			}
+		})
+	}
+}
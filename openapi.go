@@ -0,0 +1,292 @@
+package sham
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+var openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// OpenAPI holds a parsed OpenAPI 3 document. Schemas are built on demand for
+// each component schema and each operation response, so a single schema that
+// cannot be converted does not prevent the rest of the document from being
+// used.
+//
+// Schemas are converted in the same way as ImportJSONSchema, with the OpenAPI
+// additions nullable, example, and examples also honored. Nullable schemas
+// become a choice with null, and example values are used in place of
+// generated values for scalars without a pattern or format.
+type OpenAPI struct {
+	root *OrderedMap
+}
+
+// ImportOpenAPI parses an OpenAPI 3 document in either YAML or JSON.
+func ImportOpenAPI(d []byte) (*OpenAPI, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(d, &doc); err != nil {
+		return nil, err
+	}
+
+	v, err := fromYAML(&doc)
+	if err != nil {
+		return nil, err
+	}
+
+	root, ok := v.(*OrderedMap)
+	if !ok {
+		return nil, errors.New("expected an OpenAPI document object")
+	}
+
+	version, _ := root.Values["openapi"].(string)
+	if !strings.HasPrefix(version, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version %q", version)
+	}
+
+	return &OpenAPI{root: root}, nil
+}
+
+// fromYAML converts a YAML node into the same representation produced by
+// decodeOrdered, preserving the order of mapping keys.
+func fromYAML(n *yaml.Node) (interface{}, error) {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return nil, errors.New("empty input")
+		}
+		return fromYAML(n.Content[0])
+	case yaml.AliasNode:
+		return fromYAML(n.Alias)
+	case yaml.MappingNode:
+		m := NewOrderedMap()
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			v, err := fromYAML(n.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			m.Set(n.Content[i].Value, v)
+		}
+		return m, nil
+	case yaml.SequenceNode:
+		arr := make([]interface{}, len(n.Content))
+		for i, c := range n.Content {
+			v, err := fromYAML(c)
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = v
+		}
+		return arr, nil
+	}
+
+	var v interface{}
+	if err := n.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	switch v := v.(type) {
+	case int:
+		return json.Number(strconv.Itoa(v)), nil
+	case float64:
+		return json.Number(strconv.FormatFloat(v, 'g', -1, 64)), nil
+	case time.Time:
+		return v.Format(time.RFC3339), nil
+	}
+	return v, nil
+}
+
+func (o *OpenAPI) converter() *jsonSchemaConverter {
+	return &jsonSchemaConverter{root: o.root, openAPI: true}
+}
+
+func (o *OpenAPI) componentSchemas() *OrderedMap {
+	components, _ := o.root.Values["components"].(*OrderedMap)
+	if components == nil {
+		return NewOrderedMap()
+	}
+
+	schemas, _ := components.Values["schemas"].(*OrderedMap)
+	if schemas == nil {
+		return NewOrderedMap()
+	}
+	return schemas
+}
+
+// Components returns the names of the component schemas in the order they
+// are defined.
+func (o *OpenAPI) Components() []string {
+	return o.componentSchemas().Keys
+}
+
+// Component builds the schema for the named component schema.
+func (o *OpenAPI) Component(name string) (Schema, error) {
+	s, ok := o.componentSchemas().Values[name]
+	if !ok {
+		return Schema{}, fmt.Errorf("unknown component %q", name)
+	}
+
+	c := o.converter()
+	c.refs = []string{"#/components/schemas/" + name}
+
+	n, err := c.convert(s)
+	if err != nil {
+		return Schema{}, fmt.Errorf("component %q: %w", name, err)
+	}
+	return Schema{Root: n}, nil
+}
+
+// Operations returns the operations in the document. Each operation is
+// identified by its method and path, such as "GET /users/{id}", in the order
+// they are defined.
+func (o *OpenAPI) Operations() []string {
+	ops := make([]string, 0)
+	paths, _ := o.root.Values["paths"].(*OrderedMap)
+	if paths == nil {
+		return ops
+	}
+
+	for _, path := range paths.Keys {
+		item, _ := paths.Values[path].(*OrderedMap)
+		if item == nil {
+			continue
+		}
+
+		for _, m := range openAPIMethods {
+			if _, ok := item.Values[m]; ok {
+				ops = append(ops, strings.ToUpper(m)+" "+path)
+			}
+		}
+	}
+	return ops
+}
+
+// Response builds the schema of an operation's response body. The operation
+// may be identified by its operationId or by its method and path, such as
+// "GET /users/{id}". If status is empty, the first successful response is
+// used. A JSON media type is preferred when a response offers several.
+func (o *OpenAPI) Response(operation, status string) (Schema, error) {
+	op, err := o.operation(operation)
+	if err != nil {
+		return Schema{}, err
+	}
+
+	responses, _ := op.Values["responses"].(*OrderedMap)
+	if responses == nil {
+		return Schema{}, fmt.Errorf("operation %q has no responses", operation)
+	}
+
+	if status == "" {
+		status = successStatus(responses.Keys)
+	}
+
+	resp, ok := responses.Values[status]
+	if !ok {
+		return Schema{}, fmt.Errorf("operation %q has no %q response", operation, status)
+	}
+
+	c := o.converter()
+	if r, ok := resp.(*OrderedMap); ok {
+		if ref, ok := r.Values["$ref"].(string); ok {
+			if resp, err = resolvePointer(o.root, ref); err != nil {
+				return Schema{}, err
+			}
+		}
+	}
+
+	s, err := responseSchema(resp)
+	if err != nil {
+		return Schema{}, fmt.Errorf("operation %q response %q: %w", operation, status, err)
+	}
+
+	n, err := c.convert(s)
+	if err != nil {
+		return Schema{}, fmt.Errorf("operation %q response %q: %w", operation, status, err)
+	}
+	return Schema{Root: n}, nil
+}
+
+func (o *OpenAPI) operation(id string) (*OrderedMap, error) {
+	paths, _ := o.root.Values["paths"].(*OrderedMap)
+	if paths == nil {
+		return nil, fmt.Errorf("unknown operation %q", id)
+	}
+
+	if i := strings.IndexByte(id, ' '); i != -1 {
+		item, _ := paths.Values[id[i+1:]].(*OrderedMap)
+		if item != nil {
+			if op, ok := item.Values[strings.ToLower(id[:i])].(*OrderedMap); ok {
+				return op, nil
+			}
+		}
+	}
+
+	for _, path := range paths.Keys {
+		item, _ := paths.Values[path].(*OrderedMap)
+		if item == nil {
+			continue
+		}
+
+		for _, m := range openAPIMethods {
+			if op, ok := item.Values[m].(*OrderedMap); ok && op.Values["operationId"] == id {
+				return op, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("unknown operation %q", id)
+}
+
+// successStatus picks the lowest 2XX status code, falling back to the
+// wildcard "2XX" and then "default".
+func successStatus(codes []string) string {
+	success := make([]string, 0)
+	for _, c := range codes {
+		if strings.HasPrefix(c, "2") {
+			success = append(success, c)
+		}
+	}
+
+	if len(success) == 0 {
+		return "default"
+	}
+
+	sort.Strings(success)
+	return success[0]
+}
+
+func responseSchema(resp interface{}) (interface{}, error) {
+	r, ok := resp.(*OrderedMap)
+	if !ok {
+		return nil, errors.New("invalid response object")
+	}
+
+	content, _ := r.Values["content"].(*OrderedMap)
+	if content == nil || len(content.Keys) == 0 {
+		return nil, errors.New("response has no content")
+	}
+
+	mediaType := content.Keys[0]
+	for _, k := range content.Keys {
+		if strings.Contains(k, "json") {
+			mediaType = k
+			break
+		}
+	}
+
+	media, _ := content.Values[mediaType].(*OrderedMap)
+	if media == nil {
+		return nil, fmt.Errorf("invalid media type %q", mediaType)
+	}
+
+	s, ok := media.Values["schema"]
+	if !ok {
+		return nil, fmt.Errorf("media type %q has no schema", mediaType)
+	}
+	return s, nil
+}
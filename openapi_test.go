@@ -0,0 +1,151 @@
+package sham
+
+import "testing"
+
+const testOpenAPI = `
+openapi: 3.0.3
+info: {title: Test, version: "1"}
+paths:
+  /users/{id}:
+    get:
+      operationId: getUser
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+        "404":
+          $ref: '#/components/responses/NotFound'
+components:
+  responses:
+    NotFound:
+      description: Not Found
+      content:
+        application/json:
+          schema: {type: object, properties: {message: {type: string, example: not found}}, required: [message]}
+  schemas:
+    User:
+      type: object
+      required: [id, name]
+      properties:
+        id: {type: integer, minimum: 1, maximum: 10}
+        name: {type: string, example: Jane Doe}
+        nickname: {type: string, pattern: "[a-z]{4}", nullable: true}
+        pet:
+          oneOf:
+            - $ref: '#/components/schemas/Cat'
+            - {type: boolean}
+    Cat:
+      type: object
+      properties: {lives: {type: integer, minimum: 1, maximum: 9}}
+      required: [lives]
+    Node:
+      type: object
+      properties:
+        next: {$ref: '#/components/schemas/Node'}
+`
+
+const testOpenAPIUser = `{
+    "id": (1,10),
+    "name": "Jane Doe",
+    "nickname"?: /[a-z]{4}/ | null,
+    "pet"?: {
+        "lives": (1,9)
+    } | boolean
+}`
+
+func TestOpenAPI_Component(t *testing.T) {
+	spec, err := ImportOpenAPI([]byte(testOpenAPI))
+	if err != nil {
+		t.Fatalf("ImportOpenAPI() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		component string
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:      "Nullable, example, and oneOf",
+			component: "User",
+			want:      testOpenAPIUser,
+		},
+		{
+			name:      "Recursive component",
+			component: "Node",
+			wantErr:   true,
+		},
+		{
+			name:      "Unknown component",
+			component: "Missing",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := spec.Component(tt.component)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("OpenAPI.Component() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.String() != tt.want {
+				t.Errorf("OpenAPI.Component() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenAPI_Response(t *testing.T) {
+	spec, err := ImportOpenAPI([]byte(testOpenAPI))
+	if err != nil {
+		t.Fatalf("ImportOpenAPI() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		operation string
+		status    string
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:      "Operation ID with default status",
+			operation: "getUser",
+			want:      testOpenAPIUser,
+		},
+		{
+			name:      "Method and path with referenced response",
+			operation: "GET /users/{id}",
+			status:    "404",
+			want: `{
+    "message": "not found"
+}`,
+		},
+		{
+			name:      "Unknown status",
+			operation: "getUser",
+			status:    "500",
+			wantErr:   true,
+		},
+		{
+			name:      "Unknown operation",
+			operation: "listUsers",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := spec.Response(tt.operation, tt.status)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("OpenAPI.Response() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.String() != tt.want {
+				t.Errorf("OpenAPI.Response() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
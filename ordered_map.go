@@ -31,9 +31,12 @@ func (m *OrderedMap) MarshalJSON() ([]byte, error) {
 	sb.WriteByte('{')
 
 	for i, k := range m.Keys {
-		sb.WriteByte('"')
-		sb.WriteString(k)
-		sb.WriteByte('"')
+		kd, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+
+		sb.Write(kd)
 		sb.WriteByte(':')
 
 		d, err := json.Marshal(m.Values[k])
@@ -50,6 +50,19 @@ func TestOrderedMap_MarshalJSON(t *testing.T) {
 			want:    []byte(`{"b":2,"c":{"f":4,"d":5,"e":6},"a":1}`),
 			wantErr: false,
 		},
+		{
+			name: "Escaped keys",
+			fields: fields{
+				Values: map[string]interface{}{
+					`say "hi"`: 1,
+					`C:\path`:  2,
+					"<&>":      3,
+				},
+				Keys: []string{`say "hi"`, `C:\path`, "<&>"},
+			},
+			want:    []byte(`{"say \"hi\"":1,"C:\\path":2,"\u003c\u0026\u003e":3}`),
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
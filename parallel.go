@@ -0,0 +1,185 @@
+package sham
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+	"runtime"
+	"sync"
+)
+
+// GenerateN performs n generations across a number of worker goroutines and
+// passes the results to fn in order. See GenerateNRand for details. The seed of
+// the batch is drawn from the global source.
+func (s Schema) GenerateN(ctx context.Context, n, workers int, fn func(v interface{}) error) error {
+	return s.GenerateNRand(ctx, globalRand, n, workers, fn)
+}
+
+// GenerateNRand performs n generations across a number of worker goroutines
+// and passes the results to fn in order. If workers is less than one,
+// GOMAXPROCS workers are used. fn is always called from the calling goroutine.
+//
+// A seed for the batch is drawn from the provided source, and every generation
+// receives its own source derived from this seed and its position in the
+// batch. The results are therefore identical regardless of the number of
+// workers, provided every terminal generator implements RandGenerator. The
+// first failure, from a generation or from fn, stops the batch and is
+// returned. The schema is compiled once and shared by the workers.
+func (s Schema) GenerateNRand(ctx context.Context, r *rand.Rand, n, workers int, fn func(v interface{}) error) error {
+	p := s.Compile()
+	return runParallel(ctx, r.Int63(), n, workers,
+		func(ctx context.Context, r *rand.Rand) (interface{}, error) { return p.GenerateE(ctx, r) },
+		fn,
+	)
+}
+
+// EncodeN performs n generations of the schema across a number of worker
+// goroutines and writes them to the stream in order. Each generation is
+// encoded by its worker, and the seed of the batch is drawn from the encoder's
+// source. See Schema.GenerateNRand for details.
+func (e *Encoder) EncodeN(ctx context.Context, s Schema, n, workers int) error {
+	p := s.Compile()
+	r := e.Rand
+	if r == nil {
+		r = globalRand
+	}
+
+	type workerState struct {
+		buf bytes.Buffer
+		enc *Encoder
+	}
+	var pool sync.Pool
+
+	err := runParallel(ctx, r.Int63(), n, workers,
+		func(ctx context.Context, r *rand.Rand) (interface{}, error) {
+			w, ok := pool.Get().(*workerState)
+			if !ok {
+				w = &workerState{}
+				w.enc = NewEncoder(&w.buf, e.format)
+				w.enc.Indent = e.Indent
+			}
+			defer pool.Put(w)
+
+			w.buf.Reset()
+			w.enc.Rand = r
+			if err := w.enc.EncodeProgram(ctx, p); err != nil {
+				return nil, err
+			}
+			return append([]byte(nil), w.buf.Bytes()...), nil
+		},
+		func(v interface{}) error {
+			_, err := e.w.Write(v.([]byte))
+			return err
+		},
+	)
+
+	if ferr := e.w.Flush(); err == nil {
+		err = ferr
+	}
+	return err
+}
+
+// parallelResult is the outcome of a single item of a parallel batch.
+type parallelResult struct {
+	index int
+	value interface{}
+	err   error
+}
+
+// runParallel runs work for n items across a pool of workers and passes the
+// results to emit in order. Each item's source is seeded from the batch seed
+// and the item's index. The number of items in flight is bounded so that
+// memory use does not grow with n when emit keeps up.
+func runParallel(
+	ctx context.Context,
+	seed int64,
+	n, workers int,
+	work func(ctx context.Context, r *rand.Rand) (interface{}, error),
+	emit func(v interface{}) error,
+) error {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > n {
+		workers = n
+	}
+	if n <= 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Results are buffered for every item in flight, so workers never block
+	// on sending a result and can exit once the batch is cancelled.
+	window := workers * 4
+	slots := make(chan struct{}, window)
+	jobs := make(chan int)
+	results := make(chan parallelResult, window)
+
+	go func() {
+		defer close(jobs)
+		for i := 0; i < n; i++ {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+
+			src := &splitMix{}
+			r := rand.New(src)
+			for i := range jobs {
+				src.Seed(itemSeed(seed, i))
+				v, err := work(ctx, r)
+				results <- parallelResult{index: i, value: v, err: err}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	pending := make(map[int]parallelResult, window)
+	next := 0
+	for res := range results {
+		pending[res.index] = res
+		for {
+			p, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+
+			if p.err != nil {
+				return p.err
+			}
+			if err := emit(p.value); err != nil {
+				return err
+			}
+
+			<-slots
+			next++
+		}
+	}
+
+	if next < n {
+		return ctx.Err()
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package sham
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestSchema_GenerateNRand(t *testing.T) {
+	s, err := NewDefaultParser([]byte(`{"name": name, "tags": [(0,3), /[a-z]{3}/], "n": (0,1000)}`)).Parse()
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	generate := func(workers int) []string {
+		var out []string
+		err := s.GenerateNRand(context.Background(), rand.New(rand.NewSource(1)), 50, workers, func(v interface{}) error {
+			d, err := json.Marshal(v)
+			out = append(out, string(d))
+			return err
+		})
+		if err != nil {
+			t.Fatalf("GenerateNRand() error = %v", err)
+		}
+		return out
+	}
+
+	want := generate(1)
+	if len(want) != 50 || want[0] == want[1] {
+		t.Fatalf("GenerateNRand() = %v", want)
+	}
+	for _, workers := range []int{0, 2, 8, 100} {
+		got := generate(workers)
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("GenerateNRand() with %d workers, item %d = %s, want %s", workers, i, got[i], want[i])
+				break
+			}
+		}
+	}
+}
+
+func TestSchema_GenerateNRand_Error(t *testing.T) {
+	errStop := errors.New("stop")
+	s, err := NewDefaultParser([]byte(`(0,9)`)).Parse()
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	calls := 0
+	err = s.GenerateNRand(context.Background(), rand.New(rand.NewSource(1)), 1000, 4, func(v interface{}) error {
+		if calls++; calls == 10 {
+			return errStop
+		}
+		return nil
+	})
+	if !errors.Is(err, errStop) || calls != 10 {
+		t.Errorf("GenerateNRand() error = %v after %d calls, want %v after 10", err, calls, errStop)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls = 0
+	err = s.GenerateNRand(ctx, rand.New(rand.NewSource(1)), 1000000, 4, func(v interface{}) error {
+		if calls++; calls == 10 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("GenerateNRand() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestEncoder_EncodeN(t *testing.T) {
+	s, err := NewDefaultParser([]byte(`{"name": name, "age": (18,99)}`)).Parse()
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	var want bytes.Buffer
+	err = s.GenerateNRand(context.Background(), rand.New(rand.NewSource(1)), 20, 1, func(v interface{}) error {
+		d, err := json.Marshal(v)
+		want.Write(append(d, '\n'))
+		return err
+	})
+	if err != nil {
+		t.Fatalf("GenerateNRand() error = %v", err)
+	}
+
+	for _, workers := range []int{1, 3} {
+		var got bytes.Buffer
+		e := NewEncoder(&got, FormatJSON)
+		e.Rand = rand.New(rand.NewSource(1))
+		if err := e.EncodeN(context.Background(), s, 20, workers); err != nil {
+			t.Fatalf("EncodeN() error = %v", err)
+		}
+
+		if got.String() != want.String() {
+			t.Errorf("EncodeN() with %d workers =\n%s\nwant\n%s", workers, got.String(), want.String())
+		}
+	}
+}
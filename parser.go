@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+
+	"github.com/mattmeyers/sham/gen"
 )
 
 // Parser maintains the internal state of the language parser. This struct takes
@@ -17,11 +19,17 @@ import (
 //
 // To ensure the parser begins with the proper state, one of the constructor functions
 // should be used.
+//
+// Terminal generators implementing LocaleGenerator produce data for the
+// parser's Locale, such as de_DE. A schema can choose its own locale with a
+// leading @locale("de_DE") directive, which takes precedence over the parser's.
 type Parser struct {
 	TerminalGenerators map[string]Generator
+	Locale             string
 	source             []byte
 	tokens             []Token
 	i                  int
+	locale             *gen.Locale
 }
 
 var errEOF = errors.New("EOF")
@@ -36,11 +44,17 @@ func NewParser(d []byte) *Parser {
 	}
 }
 
-// NewDefaultParser creates a new Parser instance using the default terminal
-// generators map.
+// NewDefaultParser creates a new Parser instance using a copy of the default
+// terminal generators map, so generators registered with the parser do not
+// leak into other parsers.
 func NewDefaultParser(d []byte) *Parser {
+	gs := make(map[string]Generator, len(TerminalGenerators))
+	for k, v := range TerminalGenerators {
+		gs[k] = v
+	}
+
 	return &Parser{
-		TerminalGenerators: TerminalGenerators,
+		TerminalGenerators: gs,
 		source:             d,
 		tokens:             make([]Token, 0),
 		i:                  0,
@@ -57,11 +71,32 @@ func (p *Parser) RegisterGenerators(gs map[string]Generator) {
 	}
 }
 
+// RegisterWordList loads the word list at path with LoadWordList and registers
+// it as a terminal generator under the given name.
+func (p *Parser) RegisterWordList(name, path string) error {
+	if tokens, err := Tokenize([]byte(name)); err != nil || len(tokens) != 1 || tokens[0].Type != TokIdent {
+		return fmt.Errorf("invalid terminal generator name %q", name)
+	}
+
+	g, err := LoadWordList(path)
+	if err != nil {
+		return err
+	}
+	p.RegisterGenerators(map[string]Generator{name: g})
+	return nil
+}
+
 func (p *Parser) current() Token {
+	if p.i >= len(p.tokens) {
+		return newToken(TokEOF, "")
+	}
 	return p.tokens[p.i]
 }
 
 func (p *Parser) peek() Token {
+	if p.i+1 >= len(p.tokens) {
+		return newToken(TokEOF, "")
+	}
 	return p.tokens[p.i+1]
 }
 
@@ -84,15 +119,84 @@ func (p *Parser) Parse() (Schema, error) {
 	}
 	p.tokens = tokens
 
+	locale, err := p.parseDirectives()
+	if err != nil {
+		return Schema{}, err
+	} else if locale == "" {
+		locale = p.Locale
+	}
+
+	if locale != "" {
+		if p.locale, err = gen.LookupLocale(locale); err != nil {
+			return Schema{}, err
+		}
+	}
+
 	root, err := p.parseValue()
 	if err != nil {
 		return Schema{}, err
 	}
 
-	return Schema{Root: root}, nil
+	return Schema{Root: root, Locale: locale}, nil
+}
+
+// parseDirectives parses the directives at the start of a schema, returning
+// the locale chosen by an @locale directive. The parser is left on the first
+// token following the directives.
+func (p *Parser) parseDirectives() (locale string, err error) {
+	for p.current().Type == TokAt {
+		t := p.advance()
+		if t.Type != TokIdent {
+			return "", fmt.Errorf("expected directive name, got %v", t)
+		}
+
+		if t := p.advance(); t.Type != TokLParen {
+			return "", fmt.Errorf(`expected "(", got %v`, t)
+		}
+		args, err := p.parseArgs()
+		if err != nil {
+			return "", err
+		}
+
+		switch t.Value {
+		case "locale":
+			if locale, err = singleStringArg(args); err != nil {
+				return "", fmt.Errorf("invalid arguments to @locale: %w", err)
+			}
+		default:
+			return "", fmt.Errorf("unknown directive @%s", t.Value)
+		}
+
+		p.advance()
+	}
+
+	return locale, nil
 }
 
+// parseValue parses a single value, or a choice between multiple values
+// separated by "|".
 func (p *Parser) parseValue() (Node, error) {
+	n, err := p.parsePrimary()
+	if err != nil || p.peek().Type != TokPipe {
+		return n, err
+	}
+
+	c := Choice{Options: []Node{n}}
+	for p.peek().Type == TokPipe {
+		p.advance()
+		p.advance()
+
+		n, err = p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		c.Options = append(c.Options, n)
+	}
+
+	return c, nil
+}
+
+func (p *Parser) parsePrimary() (Node, error) {
 	var n Node
 	var err error
 
@@ -125,6 +229,8 @@ func (p *Parser) parseValue() (Node, error) {
 		n = Literal{Value: false}
 	case TokEOF:
 		err = errors.New("empty input")
+	default:
+		err = fmt.Errorf("unexpected token %v", t)
 	}
 
 	if err != nil {
@@ -139,6 +245,7 @@ func (p *Parser) parseObject() (Object, error) {
 	t := p.current()
 
 	if p.peek().Type == TokRBrace {
+		p.advance()
 		return obj, nil
 	}
 
@@ -148,12 +255,12 @@ func (p *Parser) parseObject() (Object, error) {
 			return Object{}, fmt.Errorf("expected string, got %v", t)
 		}
 
-		k, n, err := p.parsePair()
+		kv, err := p.parsePair()
 		if err != nil {
 			return Object{}, err
 		}
 
-		obj.AppendPair(k, n)
+		obj.Values = append(obj.Values, kv)
 
 		t = p.advance()
 		if t.Type != TokRBrace && t.Type != TokComma {
@@ -166,22 +273,28 @@ func (p *Parser) parseObject() (Object, error) {
 	return obj, nil
 }
 
-func (p *Parser) parsePair() (string, Node, error) {
-	t := p.current()
-	key := t.Value
+func (p *Parser) parsePair() (KV, error) {
+	kv := KV{Key: p.current().Value}
 
-	if t := p.advance(); t.Type != TokColon {
-		return "", nil, fmt.Errorf("expected \":\", got %v", t)
+	t := p.advance()
+	if t.Type == TokQuestion {
+		kv.Optional = true
+		t = p.advance()
+	}
+
+	if t.Type != TokColon {
+		return KV{}, fmt.Errorf("expected \":\", got %v", t)
 	}
 
 	p.advance()
 
 	n, err := p.parseValue()
 	if err != nil {
-		return "", nil, err
+		return KV{}, err
 	}
+	kv.Value = n
 
-	return key, n, nil
+	return kv, nil
 }
 
 func (p *Parser) parseArray() (Array, error) {
@@ -275,7 +388,7 @@ func (p *Parser) parseFString() (FormattedString, error) {
 	params := make([]Generator, len(matches))
 
 	for i, m := range matches {
-		g, ok := TerminalGenerators[m[1:len(m)-1]]
+		g, ok := p.lookup(m[1 : len(m)-1])
 		if !ok {
 			return FormattedString{}, fmt.Errorf("unknown terminal generator %s in formatted string", m)
 		}
@@ -319,11 +432,93 @@ func (p *Parser) parseFloat() (Literal, error) {
 	return Literal{Value: f}, nil
 }
 
+// lookup finds the registered terminal generator with the given name, adapted
+// to the schema's locale.
+func (p *Parser) lookup(name string) (Generator, bool) {
+	g, ok := p.TerminalGenerators[name]
+	if !ok {
+		return nil, false
+	}
+	if lg, ok := g.(LocaleGenerator); ok && p.locale != nil {
+		g = lg.WithLocale(p.locale)
+	}
+	return g, true
+}
+
 func (p *Parser) parseIdent() (TerminalGenerator, error) {
 	n := p.current().Value
-	fn, ok := p.TerminalGenerators[n]
+	fn, ok := p.lookup(n)
 	if !ok {
 		return TerminalGenerator{}, fmt.Errorf("unknown terminal generator %q", n)
 	}
-	return TerminalGenerator{Name: n, fn: fn}, nil
+	t := TerminalGenerator{Name: n, fn: fn}
+
+	if p.peek().Type != TokLParen {
+		return t, nil
+	}
+	p.advance()
+
+	args, err := p.parseArgs()
+	if err != nil {
+		return TerminalGenerator{}, err
+	} else if len(args) == 0 {
+		return t, nil
+	}
+
+	ag, ok := fn.(ArgGenerator)
+	if !ok {
+		return TerminalGenerator{}, fmt.Errorf("terminal generator %q does not accept arguments", n)
+	}
+
+	t.fn, err = ag.WithArgs(args)
+	if err != nil {
+		return TerminalGenerator{}, fmt.Errorf("invalid arguments to %s: %w", n, err)
+	}
+	t.Args = args
+
+	return t, nil
+}
+
+// parseArgs parses the comma separated literal arguments of a terminal
+// generator, up to and including the closing parenthesis.
+func (p *Parser) parseArgs() ([]interface{}, error) {
+	var args []interface{}
+	if p.peek().Type == TokRParen {
+		p.advance()
+		return args, nil
+	}
+
+	for {
+		var l Literal
+		var err error
+
+		switch t := p.advance(); t.Type {
+		case TokInteger:
+			l, err = p.parseInteger()
+		case TokFloat:
+			l, err = p.parseFloat()
+		case TokString:
+			l = Literal{Value: t.Value}
+		case TokNull:
+			l = Literal{Value: nil}
+		case TokTrue:
+			l = Literal{Value: true}
+		case TokFalse:
+			l = Literal{Value: false}
+		default:
+			return nil, fmt.Errorf("expected argument, got %v", t)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, l.Value)
+
+		t := p.advance()
+		if t.Type != TokRParen && t.Type != TokComma {
+			return nil, fmt.Errorf(`expected "," or ")", got %v`, t)
+		} else if t.Type == TokRParen {
+			return args, nil
+		}
+	}
 }
@@ -0,0 +1,177 @@
+package sham
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const indent = "    "
+
+// String renders the schema as Sham source. The output is formatted with one
+// object pair per line and can be parsed again with a Parser, provided the
+// same terminal generators are registered.
+func (s Schema) String() string {
+	var sb strings.Builder
+	if s.Locale != "" {
+		sb.WriteString("@locale(")
+		writeLiteral(&sb, s.Locale)
+		sb.WriteString(")\n")
+	}
+	writeNode(&sb, s.Root, 0)
+	return sb.String()
+}
+
+func writeNode(sb *strings.Builder, n Node, depth int) {
+	switch n := n.(type) {
+	case nil:
+		sb.WriteString("null")
+	case Object:
+		writeObject(sb, n, depth)
+	case Array:
+		writeArray(sb, n, depth)
+	case Choice:
+		for i, o := range n.Options {
+			if i > 0 {
+				sb.WriteString(" | ")
+			}
+			writeNode(sb, o, depth)
+		}
+	case Range:
+		writeRange(sb, n)
+	case Regex:
+		sb.WriteByte('/')
+		writeRegexPattern(sb, n.Pattern)
+		sb.WriteByte('/')
+	case FormattedString:
+		sb.WriteByte('`')
+		sb.WriteString(n.Raw)
+		sb.WriteByte('`')
+	case TerminalGenerator:
+		sb.WriteString(n.Name)
+		if len(n.Args) > 0 {
+			sb.WriteByte('(')
+			for i, a := range n.Args {
+				if i > 0 {
+					sb.WriteString(", ")
+				}
+				writeLiteral(sb, a)
+			}
+			sb.WriteByte(')')
+		}
+	case Literal:
+		if s, ok := n.Value.(string); ok && !isQuotable(s) {
+			// A regular expression matching only s generates the same value.
+			writeNode(sb, MustRegex(quoteRegex(s)), depth)
+			return
+		}
+		writeLiteral(sb, n.Value)
+	default:
+		fmt.Fprintf(sb, "%v", n)
+	}
+}
+
+func writeObject(sb *strings.Builder, o Object, depth int) {
+	if len(o.Values) == 0 {
+		sb.WriteString("{}")
+		return
+	}
+
+	sb.WriteString("{\n")
+	for i, kv := range o.Values {
+		sb.WriteString(strings.Repeat(indent, depth+1))
+		sb.WriteByte('"')
+		sb.WriteString(kv.Key)
+		sb.WriteByte('"')
+		if kv.Optional {
+			sb.WriteByte('?')
+		}
+		sb.WriteString(": ")
+		writeNode(sb, kv.Value, depth+1)
+		if i < len(o.Values)-1 {
+			sb.WriteByte(',')
+		}
+		sb.WriteByte('\n')
+	}
+	sb.WriteString(strings.Repeat(indent, depth))
+	sb.WriteByte('}')
+}
+
+func writeArray(sb *strings.Builder, a Array, depth int) {
+	if a.Inner == nil {
+		sb.WriteString("[]")
+		return
+	}
+
+	sb.WriteByte('[')
+	if a.Range != nil {
+		writeRange(sb, *a.Range)
+		sb.WriteString(", ")
+	}
+	writeNode(sb, a.Inner, depth)
+	sb.WriteByte(']')
+}
+
+func writeRange(sb *strings.Builder, r Range) {
+	if r.Min == r.Max {
+		fmt.Fprintf(sb, "(%d)", r.Min)
+		return
+	}
+	fmt.Fprintf(sb, "(%d,%d)", r.Min, r.Max)
+}
+
+func writeLiteral(sb *strings.Builder, v interface{}) {
+	switch v := v.(type) {
+	case nil:
+		sb.WriteString("null")
+	case string:
+		// The Sham language does not support escape sequences in string
+		// literals, so the value is written exactly as it was provided.
+		sb.WriteByte('"')
+		sb.WriteString(v)
+		sb.WriteByte('"')
+	case float64:
+		s := strconv.FormatFloat(v, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".eE") {
+			s += ".0"
+		}
+		sb.WriteString(s)
+	default:
+		d, err := json.Marshal(v)
+		if err != nil {
+			fmt.Fprintf(sb, "%v", v)
+			return
+		}
+		sb.Write(d)
+	}
+}
+
+// isQuotable reports whether s can be written as a string literal. The Sham
+// language has no escape sequences, so a string holding a double quote cannot.
+func isQuotable(s string) bool {
+	return !strings.ContainsRune(s, '"')
+}
+
+// writeRegexPattern writes a pattern so that it can be read back between
+// slashes. Unescaped slashes are escaped, and a trailing escaped backslash is
+// written as \x5c, since the scanner would read it as escaping the closing
+// slash.
+func writeRegexPattern(sb *strings.Builder, pattern string) {
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case c == '\\' && i+1 < len(pattern):
+			if pattern[i+1] == '\\' && i+2 == len(pattern) {
+				sb.WriteString(`\x5c`)
+			} else {
+				sb.WriteByte(c)
+				sb.WriteByte(pattern[i+1])
+			}
+			i++
+		case c == '/':
+			sb.WriteString(`\/`)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package sham
+
+import "testing"
+
+func TestSchema_String_Regex(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{pattern: `https?://[a-z]+/`, want: `/https?:\/\/[a-z]+\//`},
+		{pattern: `a\/b`, want: `/a\/b/`},
+		{pattern: `dir\\`, want: `/dir\x5c/`},
+		{pattern: `[\\/]\\\/`, want: `/[\\\/]\\\//`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			s := Schema{Root: MustRegex(tt.pattern)}
+			if got := s.String(); got != tt.want {
+				t.Errorf("String() = %s, want %s", got, tt.want)
+			}
+
+			reparsed, err := NewDefaultParser([]byte(s.String())).Parse()
+			if err != nil {
+				t.Fatalf("Parse(%s) error = %v", s, err)
+			}
+			if reparsed.String() != s.String() {
+				t.Errorf("reparsed schema = %s, want %s", reparsed, s)
+			}
+
+			want := MustRegex(tt.pattern).regex.String()
+			if got := reparsed.Root.(Regex).regex.String(); got != want {
+				t.Errorf("reparsed regex = %s, want %s", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package sham
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SchemaFor builds a schema describing values of the Go type t using the
+// default terminal generators. See Parser.SchemaFor for details.
+func SchemaFor(t reflect.Type) (Schema, error) {
+	return NewDefaultParser(nil).SchemaFor(t)
+}
+
+// SchemaFor builds a schema describing values of the Go type t. Structs become
+// objects keyed by their json tags, following the same rules as encoding/json,
+// and every other type receives a sensible default:
+//
+//   - strings generate short lowercase words
+//   - integers and floats generate integers in the range (0,100)
+//   - booleans use the boolean terminal generator
+//   - time.Time uses the timestamp terminal generator
+//   - slices generate between 0 and 5 elements, and arrays exactly fill the
+//     array
+//   - pointers generate their element type
+//
+// The generated node of a struct field can be overridden with a sham tag
+// holding a Sham value, such as `sham:"name"`, `sham:"(18,99)"` or
+// `sham:"/[A-Z]{3}/"`. The value is parsed with the parser's terminal
+// generators and locale. A field tagged `sham:"-"` is omitted.
+//
+// Recursive types generate null, or an empty array or object, once a type is
+// encountered within itself. Types that cannot be represented, such as
+// channels and functions, result in an error unless the field is tagged.
+func (p *Parser) SchemaFor(t reflect.Type) (Schema, error) {
+	b := schemaBuilder{parser: p, seen: make(map[reflect.Type]bool)}
+
+	n, err := b.node(t)
+	if err != nil {
+		return Schema{}, err
+	}
+	return Schema{Root: n, Locale: p.Locale}, nil
+}
+
+// schemaBuilder maintains the state needed to build a schema from a Go type.
+// The types currently being built are tracked to detect recursive types.
+type schemaBuilder struct {
+	parser *Parser
+	seen   map[reflect.Type]bool
+}
+
+func (b schemaBuilder) node(t reflect.Type) (Node, error) {
+	if t == timeType {
+		return b.terminal("timestamp")
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		if b.seen[t.Elem()] {
+			return Literal{Value: nil}, nil
+		}
+		return b.node(t.Elem())
+	case reflect.Struct:
+		return b.structNode(t)
+	case reflect.Slice:
+		if b.seen[t.Elem()] {
+			return Array{}, nil
+		}
+
+		inner, err := b.node(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return Array{Range: &Range{Min: 0, Max: defaultMaxItems}, Inner: inner}, nil
+	case reflect.Array:
+		inner, err := b.node(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return Array{Range: &Range{Min: t.Len(), Max: t.Len()}, Inner: inner}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map key type %v", t.Key())
+		}
+		return Object{}, nil
+	case reflect.Interface:
+		return Literal{Value: nil}, nil
+	case reflect.String:
+		return MustRegex(fmt.Sprintf("[a-z]{%d,%d}", defaultMinLength, defaultMaxLength)), nil
+	case reflect.Bool:
+		return b.terminal("boolean")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return Range{Min: 0, Max: defaultNumberSpan}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %v", t)
+}
+
+func (b schemaBuilder) structNode(t reflect.Type) (Node, error) {
+	b.seen[t] = true
+	defer delete(b.seen, t)
+
+	obj := Object{}
+	for _, f := range jsonFields(t) {
+		tag, ok := f.field.Tag.Lookup("sham")
+		if tag == "-" {
+			continue
+		}
+
+		var n Node
+		var err error
+		if ok {
+			n, err = b.parseTag(tag)
+		} else {
+			n, err = b.node(f.field.Type)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%v.%s: %w", t, f.field.Name, err)
+		}
+
+		obj.AppendPair(f.name, n)
+	}
+
+	return obj, nil
+}
+
+func (b schemaBuilder) parseTag(tag string) (Node, error) {
+	p := NewParser([]byte(strings.TrimSpace(tag)))
+	p.TerminalGenerators = b.parser.TerminalGenerators
+	p.Locale = b.parser.Locale
+
+	s, err := p.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("invalid sham tag %q: %w", tag, err)
+	}
+	return s.Root, nil
+}
+
+func (b schemaBuilder) terminal(name string) (Node, error) {
+	g, ok := b.parser.TerminalGenerators[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown terminal generator %q", name)
+	}
+	return TerminalGenerator{Name: name, fn: g}, nil
+}
@@ -0,0 +1,126 @@
+package sham
+
+import (
+	"math/rand"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type reflectAddress struct {
+	Code string `json:"code" sham:"/[A-Z]{3}/"`
+}
+
+type reflectUser struct {
+	fillBase
+	Name     string            `json:"name" sham:"name"`
+	Age      int               `json:"age" sham:"(18,99)"`
+	Admin    bool              `json:"admin"`
+	Created  time.Time         `json:"created"`
+	Address  *reflectAddress   `json:"address"`
+	Tags     []string          `json:"tags"`
+	Pair     [2]float64        `json:"pair"`
+	Meta     map[string]string `json:"meta"`
+	Internal string            `json:"internal" sham:"-"`
+	Parent   *reflectUser      `json:"parent"`
+	Fn       func()            `json:"-"`
+	private  int
+}
+
+func TestSchemaFor(t *testing.T) {
+	tests := []struct {
+		name    string
+		typ     reflect.Type
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Struct with tags and defaults",
+			typ:  reflect.TypeOf(reflectUser{}),
+			want: `{
+    "id": (0,100),
+    "name": name,
+    "age": (18,99),
+    "admin": boolean,
+    "created": timestamp,
+    "address": {
+        "code": /[A-Z]{3}/
+    },
+    "tags": [(0,5), /[a-z]{5,10}/],
+    "pair": [(2), (0,100)],
+    "meta": {},
+    "parent": null
+}`,
+		},
+		{
+			name: "Slice of pointers",
+			typ:  reflect.TypeOf([]*reflectAddress{}),
+			want: `[(0,5), {
+    "code": /[A-Z]{3}/
+}]`,
+		},
+		{
+			name: "Invalid tag",
+			typ: reflect.TypeOf(struct {
+				A int `sham:"(5,1)"`
+			}{}),
+			wantErr: true,
+		},
+		{
+			name: "Unknown terminal generator",
+			typ: reflect.TypeOf(struct {
+				A string `sham:"unknown"`
+			}{}),
+			wantErr: true,
+		},
+		{
+			name:    "Unsupported type",
+			typ:     reflect.TypeOf(struct{ C chan int }{}),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SchemaFor(tt.typ)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SchemaFor() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.String() != tt.want {
+				t.Errorf("SchemaFor() = %s, want %s", got, tt.want)
+			}
+
+			if err := got.Fill(reflect.New(tt.typ).Interface()); err != nil {
+				t.Errorf("Schema.Fill() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestParser_SchemaFor_Locale(t *testing.T) {
+	p := NewDefaultParser(nil)
+	p.Locale = "fr_FR"
+
+	s, err := p.SchemaFor(reflect.TypeOf(struct {
+		Phone string `json:"phone" sham:"phoneNumber"`
+	}{}))
+	if err != nil {
+		t.Fatalf("SchemaFor() error = %v", err)
+	}
+	if s.Locale != "fr_FR" {
+		t.Errorf("SchemaFor() locale = %q, want fr_FR", s.Locale)
+	}
+
+	want := regexp.MustCompile(`^(0[1-9]|\+33 [1-9])( \d{2}){4}$`)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		v := s.GenerateRand(r).(*OrderedMap).Values["phone"].(string)
+		if !want.MatchString(v) {
+			t.Fatalf("GenerateRand() phone = %q", v)
+		}
+	}
+}
@@ -7,6 +7,17 @@ import (
 
 const maxRepeats int = 10
 
+// MustRegex is like NewRegex but panics if the pattern cannot be parsed. It
+// simplifies the initialization of global variables holding regular
+// expressions.
+func MustRegex(pattern string) Regex {
+	r, err := NewRegex(pattern)
+	if err != nil {
+		panic("sham: " + err.Error())
+	}
+	return r
+}
+
 // NewRegex parses a regular expression. Regular expressions are of the Go flavor
 // and use Perl flags.
 func NewRegex(pattern string) (Regex, error) {
@@ -34,40 +45,51 @@ type Regex struct {
 // Generate traverses a parsed regular expression and generates data where
 // applicable.
 func (r Regex) Generate() interface{} {
-	return string(r.gen(r.regex))
+	return r.GenerateString(globalRand)
+}
+
+// GenerateRand traverses a parsed regular expression using the provided
+// source.
+func (r Regex) GenerateRand(rnd *rand.Rand) interface{} {
+	return r.GenerateString(rnd)
+}
+
+// GenerateString is like GenerateRand, but returns the generated string
+// without boxing it in an interface.
+func (r Regex) GenerateString(rnd *rand.Rand) string {
+	return string(r.gen(rnd, r.regex))
 }
 
-func (r Regex) gen(re *syntax.Regexp) []rune {
+func (r Regex) gen(rnd *rand.Rand, re *syntax.Regexp) []rune {
 	rs := make([]rune, 0)
 	switch re.Op {
 	case syntax.OpLiteral:
 		return re.Rune
 	case syntax.OpStar:
-		n := rand.Intn(maxRepeats)
+		n := rnd.Intn(maxRepeats)
 		for i := 0; i < n; i++ {
-			rs = append(rs, r.gen(re.Sub0[0])...)
+			rs = append(rs, r.gen(rnd, re.Sub0[0])...)
 		}
 	case syntax.OpPlus:
-		n := rand.Intn(maxRepeats-1) + 1
+		n := rnd.Intn(maxRepeats-1) + 1
 		for i := 0; i < n; i++ {
-			rs = append(rs, r.gen(re.Sub0[0])...)
+			rs = append(rs, r.gen(rnd, re.Sub0[0])...)
 		}
 	case syntax.OpConcat:
 		for _, s := range re.Sub {
-			rs = append(rs, r.gen(s)...)
+			rs = append(rs, r.gen(rnd, s)...)
 		}
 	case syntax.OpAlternate:
-		return r.gen(re.Sub[rand.Intn(len(re.Sub))])
+		return r.gen(rnd, re.Sub[rnd.Intn(len(re.Sub))])
 	case syntax.OpCapture:
-		return r.gen(re.Sub0[0])
+		return r.gen(rnd, re.Sub0[0])
 	case syntax.OpEmptyMatch:
 		return nil
 	case syntax.OpCharClass:
-		r := fromCharClass(re.Rune)
-		rs = append(rs, r)
+		rs = append(rs, fromCharClass(rnd, re.Rune))
 	case syntax.OpQuest:
-		if rand.Float64() < 0.75 {
-			return r.gen(re.Sub0[0])
+		if rnd.Float64() >= 0.25 {
+			return r.gen(rnd, re.Sub0[0])
 		}
 		return nil
 	}
@@ -75,11 +97,27 @@ func (r Regex) gen(re *syntax.Regexp) []rune {
 	return rs
 }
 
-func fromCharClass(class []rune) rune {
-	if len(class) == 0 {
+// fromCharClass picks a random rune from a character class. The class is a
+// list of inclusive [lo, hi] pairs, and every rune in the class is equally
+// likely to be chosen.
+func fromCharClass(rnd *rand.Rand, class []rune) rune {
+	if len(class) < 2 {
 		return 0
 	}
-	min := class[0]
-	max := class[len(class)-1]
-	return rune(rand.Int31n(max-min) + min)
+
+	var size int32
+	for i := 0; i+1 < len(class); i += 2 {
+		size += class[i+1] - class[i] + 1
+	}
+
+	n := rnd.Int31n(size)
+	for i := 0; i+1 < len(class); i += 2 {
+		width := class[i+1] - class[i] + 1
+		if n < width {
+			return class[i] + n
+		}
+		n -= width
+	}
+
+	return class[0]
 }
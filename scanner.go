@@ -112,6 +112,12 @@ func (s *Scanner) Scan() (tok TokenType, lit string) {
 		return TokColon, string(ch)
 	case ',':
 		return TokComma, string(ch)
+	case '?':
+		return TokQuestion, string(ch)
+	case '|':
+		return TokPipe, string(ch)
+	case '@':
+		return TokAt, string(ch)
 	case '"':
 		return TokString, s.scanString(QuoteDouble)
 	case '`':
@@ -128,7 +134,7 @@ func (s *Scanner) scanIdent() (TokenType, string) {
 	for {
 		if ch := s.read(); ch == eof {
 			break
-		} else if !isAlpha(ch) {
+		} else if !isAlphaNumeric(ch) {
 			s.unread()
 			break
 		} else {
@@ -189,6 +195,7 @@ func (s *Scanner) scanNumber() (TokenType, string) {
 	}
 
 	if ch == '0' {
+		s.buf.WriteRune(ch)
 		ch = s.read()
 		if isDigit(ch) {
 			return TokInvalid, string(ch)
@@ -60,6 +60,33 @@ func TestTokenize(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:   "Tokenize optional pair and choice",
+			source: []byte(`{"a"?: 1 | null}`),
+			want: []Token{
+				{Type: TokLBrace, Value: "{"},
+				{Type: TokString, Value: "a"},
+				{Type: TokQuestion, Value: "?"},
+				{Type: TokColon, Value: ":"},
+				{Type: TokInteger, Value: "1"},
+				{Type: TokPipe, Value: "|"},
+				{Type: TokNull, Value: "null"},
+				{Type: TokRBrace, Value: "}"},
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Tokenize identifiers containing digits",
+			source: []byte(`[ipv4, ipv6]`),
+			want: []Token{
+				{Type: TokLBracket, Value: "["},
+				{Type: TokIdent, Value: "ipv4"},
+				{Type: TokComma, Value: ","},
+				{Type: TokIdent, Value: "ipv6"},
+				{Type: TokRBracket, Value: "]"},
+			},
+			wantErr: false,
+		},
 		{
 			name:   "Tokenize fstring",
 			source: []byte("`foo ${bar}`"),
@@ -76,6 +103,29 @@ func TestTokenize(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:   "Tokenize zero",
+			source: []byte(`0 | 0.5`),
+			want: []Token{
+				{Type: TokInteger, Value: "0"},
+				{Type: TokPipe, Value: "|"},
+				{Type: TokFloat, Value: "0.5"},
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Tokenize directive",
+			source: []byte(`@locale("de_DE") name`),
+			want: []Token{
+				{Type: TokAt, Value: "@"},
+				{Type: TokIdent, Value: "locale"},
+				{Type: TokLParen, Value: "("},
+				{Type: TokString, Value: "de_DE"},
+				{Type: TokRParen, Value: ")"},
+				{Type: TokIdent, Value: "name"},
+			},
+			wantErr: false,
+		},
 		{
 			name:    "Unterminated string",
 			source:  []byte(`"abc`),
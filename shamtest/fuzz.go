@@ -0,0 +1,59 @@
+//go:build go1.18
+// +build go1.18
+
+package shamtest
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/mattmeyers/sham"
+)
+
+// fuzzSeeds is the number of random inputs added to the seed corpus.
+const fuzzSeeds = 8
+
+// Fuzz runs a fuzz test whose inputs are shaped by the schema. Rather than
+// passing raw bytes to fn, the fuzzer's bytes drive the random decisions made
+// while generating from s, so every input is a valid value. Mutating the bytes
+// explores different array lengths, range values, regular expression
+// repetitions and choices. The seed corpus is populated with inputs derived
+// from the fuzz test's seed, along with an empty input producing the smallest
+// possible value.
+//
+//	func FuzzHandler(f *testing.F) {
+//		s := shamtest.MustParse(f, `{"name": name, "age": (0,150)}`)
+//		shamtest.Fuzz(f, s, func(t *testing.T, v interface{}) {
+//			...
+//		})
+//	}
+func Fuzz(f *testing.F, s sham.Schema, fn func(t *testing.T, v interface{})) {
+	f.Helper()
+
+	f.Add([]byte{})
+	r := rand.New(rand.NewSource(Seed(f)))
+	for i := 0; i < fuzzSeeds; i++ {
+		b := make([]byte, 64)
+		_, _ = r.Read(b)
+		f.Add(b)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fn(t, s.GenerateRand(rand.New(sham.NewByteSource(data))))
+	})
+}
+
+// FuzzJSON is like Fuzz, but passes the JSON encoding of each generated value
+// to fn. It is convenient for fuzzing handlers that decode JSON.
+func FuzzJSON(f *testing.F, s sham.Schema, fn func(t *testing.T, data []byte)) {
+	f.Helper()
+
+	Fuzz(f, s, func(t *testing.T, v interface{}) {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("shamtest: cannot encode generated data: %v", err)
+		}
+		fn(t, data)
+	})
+}
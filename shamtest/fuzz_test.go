@@ -0,0 +1,28 @@
+//go:build go1.18
+// +build go1.18
+
+package shamtest
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func FuzzFuzzJSON(f *testing.F) {
+	s := MustParse(f, `{"name": name, "age": (18,99), "tags": [(0,3), /[a-z]{1,4}/]}`)
+
+	FuzzJSON(f, s, func(t *testing.T, data []byte) {
+		var v struct {
+			Name string   `json:"name"`
+			Age  int      `json:"age"`
+			Tags []string `json:"tags"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			t.Fatalf("json.Unmarshal() error = %v", err)
+		}
+
+		if v.Age < 18 || v.Age > 99 || len(v.Tags) > 3 {
+			t.Errorf("generated value %s is outside of the schema", data)
+		}
+	})
+}
@@ -0,0 +1,148 @@
+// Package shamtest provides helpers for generating fixtures with Sham in Go
+// tests. Every test receives a deterministic seed derived from its name, so a
+// failing test generates the same data each time it is run. The seed is
+// logged when a test fails, and can be overridden with the -shamtest.seed flag
+// to reproduce a failure seen elsewhere.
+//
+// Generated data can also be snapshotted in golden files. Running the tests
+// with the -shamtest.update flag rewrites the golden files with the current
+// data.
+package shamtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"hash/fnv"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mattmeyers/sham"
+)
+
+var (
+	update = flag.Bool("shamtest.update", false, "update shamtest golden files")
+	seed   = flag.Int64("shamtest.seed", 0, "override the seed used by every shamtest helper")
+)
+
+// logged records the tests that will log their seed on failure, ensuring the
+// seed is only logged once per test.
+var logged sync.Map
+
+// Seed returns the seed used for the test. Unless overridden by the
+// -shamtest.seed flag, the seed is derived from the test's name, so it is
+// stable across runs and unaffected by the order tests run in.
+func Seed(t testing.TB) int64 {
+	if *seed != 0 {
+		return *seed
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(t.Name()))
+	return int64(h.Sum64())
+}
+
+// Rand returns a source seeded with the test's seed. If the test fails, the
+// seed is logged so the failure can be reproduced. Each call returns a new
+// source, so repeated calls within a test produce the same sequence.
+func Rand(t testing.TB) *rand.Rand {
+	t.Helper()
+
+	s := Seed(t)
+	if _, loaded := logged.LoadOrStore(t, true); !loaded {
+		t.Cleanup(func() {
+			logged.Delete(t)
+			if t.Failed() {
+				t.Logf("shamtest: generated data with seed %d (rerun with -shamtest.seed=%d)", s, s)
+			}
+		})
+	}
+
+	return rand.New(rand.NewSource(s))
+}
+
+// MustParse parses a schema using the default terminal generators. The test
+// is stopped if the schema is invalid.
+func MustParse(t testing.TB, schema string) sham.Schema {
+	t.Helper()
+
+	s, err := sham.NewDefaultParser([]byte(schema)).Parse()
+	if err != nil {
+		t.Fatalf("shamtest: invalid schema: %v", err)
+	}
+	return s
+}
+
+// MustGenerate performs a single generation using the test's seed.
+func MustGenerate(t testing.TB, s sham.Schema) interface{} {
+	t.Helper()
+
+	return s.GenerateRand(Rand(t))
+}
+
+// MustFill performs a single generation using the test's seed and stores the
+// result in the value pointed to by v. The test is stopped if the value
+// cannot be stored.
+func MustFill(t testing.TB, s sham.Schema, v interface{}) {
+	t.Helper()
+
+	if err := s.FillRand(Rand(t), v); err != nil {
+		t.Fatalf("shamtest: %v", err)
+	}
+}
+
+// Golden compares the JSON encoding of v with the golden file for the test,
+// stored as testdata/<test name>.golden. When the -shamtest.update flag is
+// provided, the golden file is written instead.
+func Golden(t testing.TB, v interface{}) {
+	t.Helper()
+
+	got, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		t.Fatalf("shamtest: cannot encode golden data: %v", err)
+	}
+	got = append(got, '\n')
+
+	path := GoldenPath(t)
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("shamtest: %v", err)
+		}
+		if err := ioutil.WriteFile(path, got, 0644); err != nil {
+			t.Fatalf("shamtest: %v", err)
+		}
+		return
+	}
+
+	want, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("shamtest: %v (run with -shamtest.update to create it)", err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("shamtest: generated data does not match %s (run with -shamtest.update to accept it)\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+// GoldenPath returns the path of the test's golden file. Subtests are stored
+// in directories named after their parent tests.
+func GoldenPath(t testing.TB) string {
+	name := strings.NewReplacer(" ", "_", ":", "_").Replace(t.Name())
+	return filepath.Join("testdata", filepath.FromSlash(name)+".golden")
+}
+
+// Check tests a property against values generated from the schema using the
+// test's seed. If the property fails, the test is stopped and the shrunk value
+// is reported.
+func Check(t testing.TB, s sham.Schema, prop func(v interface{}) error) {
+	t.Helper()
+
+	if err := (sham.Checker{Seed: Seed(t)}).Check(s, prop); err != nil {
+		t.Fatalf("shamtest: %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package shamtest
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/mattmeyers/sham"
+)
+
+const testSchema = `{"name": name, "age": (18,99), "tags": [(1,3), /[a-z]{4}/]}`
+
+func TestSeed(t *testing.T) {
+	var seeds []int64
+	for _, name := range []string{"a", "b"} {
+		t.Run(name, func(t *testing.T) {
+			if Seed(t) != Seed(t) {
+				t.Errorf("Seed() is not stable")
+			}
+			seeds = append(seeds, Seed(t))
+		})
+	}
+
+	if seeds[0] == seeds[1] {
+		t.Errorf("Seed() = %d for different tests", seeds[0])
+	}
+}
+
+func TestMustGenerate(t *testing.T) {
+	s := MustParse(t, testSchema)
+
+	a, b := MustGenerate(t, s), MustGenerate(t, s)
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("MustGenerate() = %v, then %v", a, b)
+	}
+}
+
+func TestMustFill(t *testing.T) {
+	var v struct {
+		Name string   `json:"name"`
+		Age  int      `json:"age"`
+		Tags []string `json:"tags"`
+	}
+	MustFill(t, MustParse(t, testSchema), &v)
+
+	if v.Name == "" || v.Age < 18 || v.Age > 99 || len(v.Tags) == 0 {
+		t.Errorf("MustFill() = %+v", v)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	Check(t, MustParse(t, testSchema), func(v interface{}) error {
+		if n := len(v.(*sham.OrderedMap).Values["tags"].([]interface{})); n < 1 || n > 3 {
+			return fmt.Errorf("%d tags", n)
+		}
+		return nil
+	})
+}
+
+func TestGolden(t *testing.T) {
+	Golden(t, MustGenerate(t, MustParse(t, testSchema)))
+}
+
+func TestFlags(t *testing.T) {
+	// Packages using shamtest commonly define their own -update flag, which
+	// must not collide with ours.
+	if flag.Lookup("update") != nil {
+		t.Errorf("shamtest registered the -update flag")
+	}
+	if flag.Lookup("shamtest.update") == nil {
+		t.Errorf("shamtest did not register the -shamtest.update flag")
+	}
+}
@@ -0,0 +1,66 @@
+package sham
+
+import (
+	"encoding/binary"
+	"math/rand"
+)
+
+// byteSource is a rand.Source that draws its values from a byte slice. Each
+// value consumes four bytes, which fill the high order bits used by methods
+// such as Intn and Float64. Once the bytes are exhausted, every value is zero.
+type byteSource struct {
+	data []byte
+}
+
+// NewByteSource returns a source whose values are read from data. Generating
+// with a *rand.Rand using this source maps the bytes onto the random decisions
+// made during generation: array lengths, range values, regular expression
+// repetitions and alternations, and choices. This is primarily useful for
+// fuzzing, where a fuzzer mutates the bytes to explore the generated values.
+//
+// When the bytes run out, every remaining decision takes its smallest option.
+// Arrays receive their minimum number of elements, ranges produce their
+// minimum value, optional keys are omitted, and choices pick their first
+// option.
+func NewByteSource(data []byte) rand.Source {
+	return &byteSource{data: data}
+}
+
+func (s *byteSource) Int63() int64 {
+	var b [4]byte
+	n := copy(b[:], s.data)
+	s.data = s.data[n:]
+
+	return int64(binary.BigEndian.Uint32(b[:])>>1) << 32
+}
+
+func (s *byteSource) Seed(int64) {}
+
+// splitMix is a small, fast rand.Source implementing the SplitMix64
+// generator. Unlike the sources returned by rand.NewSource, reseeding is free,
+// so each item of a parallel batch can be given its own stream.
+type splitMix struct {
+	state uint64
+}
+
+func (s *splitMix) Seed(seed int64) { s.state = uint64(seed) }
+
+func (s *splitMix) Uint64() uint64 {
+	s.state += 0x9e3779b97f4a7c15
+	return mix64(s.state)
+}
+
+func (s *splitMix) Int63() int64 { return int64(s.Uint64() >> 1) }
+
+// mix64 is the SplitMix64 finalizer, which scrambles the bits of its input.
+func mix64(z uint64) uint64 {
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// itemSeed derives the seed of the i-th item of a batch from the batch's
+// seed, so the item's data does not depend on which worker generates it.
+func itemSeed(seed int64, i int) int64 {
+	return int64(mix64(uint64(seed) ^ mix64(uint64(i))))
+}
@@ -0,0 +1,52 @@
+package sham
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestNewByteSource(t *testing.T) {
+	s, err := NewDefaultParser([]byte(`{"a": [(1,5), (10,20)], "b": "x" | "y" | "z", "c"?: /[a-z]{2,4}/}`)).Parse()
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{
+			name: "Empty input takes the smallest options",
+			data: nil,
+			want: `{"a":[10],"b":"x"}`,
+		},
+		{
+			name: "Bytes select the options",
+			data: []byte{
+				0, 0, 0, 8, // five elements
+				0, 0, 0, 2, 0, 0, 0, 4, 0, 0, 0, 6, 0, 0, 0, 8, 0, 0, 0, 10,
+				0, 0, 0, 2, // "y"
+				0, 0, 0, 0, // omit "c"
+			},
+			want: `{"a":[11,12,13,14,15],"b":"y"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.GenerateRand(rand.New(NewByteSource(tt.data))).(*OrderedMap).MarshalJSON()
+			if err != nil {
+				t.Fatalf("MarshalJSON() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("GenerateRand() = %s, want %s", got, tt.want)
+			}
+
+			again := s.GenerateRand(rand.New(NewByteSource(tt.data)))
+			if g, _ := again.(*OrderedMap).MarshalJSON(); !reflect.DeepEqual(g, got) {
+				t.Errorf("GenerateRand() = %s, then %s", got, g)
+			}
+		})
+	}
+}
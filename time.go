@@ -0,0 +1,434 @@
+package sham
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattmeyers/sham/gen"
+)
+
+// timeLayouts names the layouts of the time package that can be given to the
+// date and time generators. Any other argument containing a % is a strftime
+// format, and anything else is a Go layout.
+var timeLayouts = map[string]string{
+	"ANSIC":       time.ANSIC,
+	"UnixDate":    time.UnixDate,
+	"RubyDate":    time.RubyDate,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"RFC850":      time.RFC850,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"Kitchen":     time.Kitchen,
+	"Stamp":       time.Stamp,
+	"StampMilli":  time.StampMilli,
+}
+
+// timeBoundLayouts are the layouts accepted for absolute time bounds.
+var timeBoundLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"}
+
+// dateAdaptor generates formatted times with f. In a schema the generator
+// takes up to three arguments: the bounds, as in "2020-01-01..now" or
+// "-30d..now", a layout, and a time zone. An empty string keeps the default for
+// that argument: times between 1970 and gen.ReferenceTime in the given layout,
+// in UTC. Relative bounds are resolved when the schema is parsed.
+func dateAdaptor(f func(*rand.Rand) string, layout string) ArgGenerator {
+	return NewArgGenerator(stringAdaptor(f), func(args []interface{}) (Generator, error) {
+		a, err := stringArgs(args, 3)
+		if err != nil {
+			return nil, err
+		}
+
+		loc, err := location(a[2])
+		if err != nil {
+			return nil, err
+		}
+
+		lo, hi := time.Unix(0, 0), gen.ReferenceTime
+		if a[0] != "" {
+			if lo, hi, err = parseTimeBounds(a[0], time.Now(), loc); err != nil {
+				return nil, err
+			}
+		}
+
+		format, err := timeFormatter(a[1], layout)
+		if err != nil {
+			return nil, err
+		}
+
+		return stringAdaptor(func(r *rand.Rand) string {
+			return format(gen.TimeBetween(r, lo, hi).In(loc))
+		}), nil
+	})
+}
+
+// shortcutTimeAdaptor formats the times generated by f as RFC 3339 in UTC.
+// In a schema the layout and time zone can be given as arguments.
+func shortcutTimeAdaptor(f func(*rand.Rand) time.Time) ArgGenerator {
+	g := func(format func(time.Time) string, loc *time.Location) RandGeneratorFunc {
+		return func(r *rand.Rand) interface{} { return format(f(r).In(loc)) }
+	}
+
+	def := func(t time.Time) string { return t.Format(time.RFC3339) }
+
+	return NewArgGenerator(g(def, time.UTC), func(args []interface{}) (Generator, error) {
+		a, err := stringArgs(args, 2)
+		if err != nil {
+			return nil, err
+		}
+
+		format, err := timeFormatter(a[0], time.RFC3339)
+		if err != nil {
+			return nil, err
+		}
+		loc, err := location(a[1])
+		if err != nil {
+			return nil, err
+		}
+		return g(format, loc), nil
+	})
+}
+
+// clockAdaptor generates times of day. In a schema the generator takes the
+// bounds as clock times, as in "09:00..17:30", and a layout.
+func clockAdaptor(f func(*rand.Rand) string) ArgGenerator {
+	return NewArgGenerator(stringAdaptor(f), func(args []interface{}) (Generator, error) {
+		a, err := stringArgs(args, 2)
+		if err != nil {
+			return nil, err
+		}
+
+		lo, hi := time.Unix(0, 0).UTC(), time.Unix(86399, 0).UTC()
+		if a[0] != "" {
+			if lo, hi, err = parseClockBounds(a[0]); err != nil {
+				return nil, err
+			}
+		}
+
+		format, err := timeFormatter(a[1], "15:04:05")
+		if err != nil {
+			return nil, err
+		}
+
+		return stringAdaptor(func(r *rand.Rand) string {
+			return format(gen.TimeBetween(r, lo, hi))
+		}), nil
+	})
+}
+
+// unixAdaptor generates Unix times in the given unit. In a schema the
+// generator takes the bounds as an argument.
+func unixAdaptor(f func(*rand.Rand) int64, unit time.Duration) ArgGenerator {
+	g := RandGeneratorFunc(func(r *rand.Rand) interface{} { return int(f(r)) })
+
+	return NewArgGenerator(g, func(args []interface{}) (Generator, error) {
+		a, err := stringArgs(args, 1)
+		if err != nil {
+			return nil, err
+		} else if a[0] == "" {
+			return g, nil
+		}
+
+		lo, hi, err := parseTimeBounds(a[0], time.Now(), time.UTC)
+		if err != nil {
+			return nil, err
+		}
+
+		if unit == time.Second {
+			return RandGeneratorFunc(func(r *rand.Rand) interface{} {
+				return int(gen.TimeBetween(r, lo, hi).Unix())
+			}), nil
+		}
+
+		min, max := lo.UnixNano()/int64(unit), hi.UnixNano()/int64(unit)
+		return RandGeneratorFunc(func(r *rand.Rand) interface{} {
+			return int(min + r.Int63n(max-min+1))
+		}), nil
+	})
+}
+
+// durationAdaptor generates durations formatted as Go durations, such as
+// 1h2m3s. In a schema the generator takes the bounds, as in "1s..2h", and the
+// output format: go, iso8601, or seconds or milliseconds as integers.
+func durationAdaptor(f func(*rand.Rand) time.Duration) ArgGenerator {
+	g := func(f func(*rand.Rand) time.Duration, format func(time.Duration) interface{}) RandGeneratorFunc {
+		return func(r *rand.Rand) interface{} { return format(f(r)) }
+	}
+	goFormat := func(d time.Duration) interface{} { return d.String() }
+
+	return NewArgGenerator(g(f, goFormat), func(args []interface{}) (Generator, error) {
+		a, err := stringArgs(args, 2)
+		if err != nil {
+			return nil, err
+		}
+
+		between := f
+		if a[0] != "" {
+			lo, hi, err := parseDurationBounds(a[0])
+			if err != nil {
+				return nil, err
+			}
+			between = func(r *rand.Rand) time.Duration { return gen.DurationBetween(r, lo, hi) }
+		}
+
+		switch a[1] {
+		case "", "go":
+			return g(between, goFormat), nil
+		case "iso8601":
+			return g(between, func(d time.Duration) interface{} { return formatISO8601(d) }), nil
+		case "seconds":
+			return g(between, func(d time.Duration) interface{} { return int(d / time.Second) }), nil
+		case "milliseconds":
+			return g(between, func(d time.Duration) interface{} { return int(d / time.Millisecond) }), nil
+		default:
+			return nil, fmt.Errorf("unknown duration format %q", a[1])
+		}
+	})
+}
+
+// formatISO8601 formats a duration as an ISO 8601 duration, such as PT1H2M3S.
+func formatISO8601(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+
+	var sb strings.Builder
+	if d < 0 {
+		sb.WriteByte('-')
+		d = -d
+	}
+	sb.WriteString("PT")
+	if h := d / time.Hour; h > 0 {
+		sb.WriteString(strconv.FormatInt(int64(h), 10) + "H")
+	}
+	if m := d % time.Hour / time.Minute; m > 0 {
+		sb.WriteString(strconv.FormatInt(int64(m), 10) + "M")
+	}
+	if s := d % time.Minute; s > 0 {
+		sb.WriteString(strconv.FormatFloat(s.Seconds(), 'f', -1, 64) + "S")
+	}
+	return sb.String()
+}
+
+// timeFormatter returns a function formatting times with the named layout,
+// strftime format or Go layout. An empty layout uses the default.
+func timeFormatter(layout, def string) (func(time.Time) string, error) {
+	if layout == "" {
+		layout = def
+	} else if l, ok := timeLayouts[layout]; ok {
+		layout = l
+	} else if strings.Contains(layout, "%") {
+		if _, err := gen.Strftime(time.Time{}, layout); err != nil {
+			return nil, err
+		}
+		return func(t time.Time) string {
+			s, _ := gen.Strftime(t, layout)
+			return s
+		}, nil
+	}
+
+	return func(t time.Time) string { return t.Format(layout) }, nil
+}
+
+// location loads the named time zone, defaulting to UTC.
+func location(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(name)
+}
+
+// parseTimeBounds parses bounds of the form min..max. Each bound is either an
+// absolute time, or a time relative to now such as now, -30d or now+1y6mo.
+// Absolute times without an offset are read in loc.
+func parseTimeBounds(s string, now time.Time, loc *time.Location) (min, max time.Time, err error) {
+	lo, hi, err := splitBounds(s)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	if min, err = parseTimeBound(lo, now, loc); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if max, err = parseTimeBound(hi, now, loc); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	if max.Before(min) {
+		return time.Time{}, time.Time{}, fmt.Errorf("bounds %q end before they start", s)
+	}
+	return min, max, nil
+}
+
+func parseTimeBound(s string, now time.Time, loc *time.Location) (time.Time, error) {
+	if strings.HasPrefix(s, "now") {
+		s = strings.TrimPrefix(s, "now")
+		if s == "" {
+			return now, nil
+		}
+	} else if s == "" || (s[0] != '+' && s[0] != '-') {
+		for _, layout := range timeBoundLayouts {
+			if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("invalid time %q", s)
+	}
+
+	years, months, d, err := parseOffset(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return now.AddDate(years, months, 0).Add(d), nil
+}
+
+// parseClockBounds parses bounds of the form 09:00..17:30:15.
+func parseClockBounds(s string) (min, max time.Time, err error) {
+	lo, hi, err := splitBounds(s)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	parse := func(s string) (time.Time, error) {
+		for _, layout := range []string{"15:04:05", "15:04"} {
+			if t, err := time.Parse(layout, s); err == nil {
+				return time.Unix(int64(t.Hour()*3600+t.Minute()*60+t.Second()), 0).UTC(), nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("invalid time of day %q", s)
+	}
+
+	if min, err = parse(lo); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if max, err = parse(hi); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	if max.Before(min) {
+		return time.Time{}, time.Time{}, fmt.Errorf("bounds %q end before they start", s)
+	}
+	return min, max, nil
+}
+
+// parseDurationBounds parses bounds of the form 1s..2h30m.
+func parseDurationBounds(s string) (min, max time.Duration, err error) {
+	lo, hi, err := splitBounds(s)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	parse := func(s string) (time.Duration, error) {
+		years, months, d, err := parseOffset(s)
+		if err == nil && (years != 0 || months != 0) {
+			err = fmt.Errorf("duration %q uses years or months, which vary in length", s)
+		}
+		return d, err
+	}
+
+	if min, err = parse(lo); err != nil {
+		return 0, 0, err
+	}
+	if max, err = parse(hi); err != nil {
+		return 0, 0, err
+	}
+
+	if min < 0 || max < min {
+		return 0, 0, fmt.Errorf("invalid duration bounds %q", s)
+	}
+	return min, max, nil
+}
+
+func splitBounds(s string) (lo, hi string, err error) {
+	parts := strings.Split(s, "..")
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("invalid bounds %q, expected min..max", s)
+	}
+	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]), nil
+}
+
+// offsetUnits are the fixed length units of an offset. Years (y) and months
+// (mo) are handled separately since their length depends on the date.
+var offsetUnits = map[string]time.Duration{
+	"w":  7 * 24 * time.Hour,
+	"d":  24 * time.Hour,
+	"h":  time.Hour,
+	"m":  time.Minute,
+	"s":  time.Second,
+	"ms": time.Millisecond,
+	"us": time.Microsecond,
+	"ns": time.Nanosecond,
+}
+
+// parseOffset parses an optionally signed sequence of numbers and units, such
+// as -1y6mo or 2d12h.
+func parseOffset(s string) (years, months int, d time.Duration, err error) {
+	orig := s
+	sign := 1
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		if s[0] == '-' {
+			sign = -1
+		}
+		s = s[1:]
+	}
+	if s == "" {
+		return 0, 0, 0, fmt.Errorf("invalid offset %q", orig)
+	}
+
+	for s != "" {
+		i := strings.IndexFunc(s, func(c rune) bool { return c < '0' || c > '9' })
+		if i <= 0 {
+			return 0, 0, 0, fmt.Errorf("invalid offset %q", orig)
+		}
+		n, err := strconv.Atoi(s[:i])
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("invalid offset %q", orig)
+		}
+		s = s[i:]
+
+		j := strings.IndexFunc(s, func(c rune) bool { return '0' <= c && c <= '9' })
+		if j < 0 {
+			j = len(s)
+		}
+		unit := s[:j]
+		s = s[j:]
+
+		switch unit {
+		case "y":
+			years += sign * n
+		case "mo":
+			months += sign * n
+		default:
+			u, ok := offsetUnits[unit]
+			if !ok {
+				return 0, 0, 0, fmt.Errorf("invalid offset %q: unknown unit %q", orig, unit)
+			}
+			d += time.Duration(sign*n) * u
+		}
+	}
+
+	return years, months, d, nil
+}
+
+// stringArgs reads up to n string arguments, filling in empty strings for
+// those that were not given.
+func stringArgs(args []interface{}, n int) ([]string, error) {
+	if len(args) > n {
+		return nil, fmt.Errorf("expected at most %d arguments, got %d", n, len(args))
+	}
+
+	out := make([]string, n)
+	for i := range args {
+		s, err := stringArg(args, i)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = s
+	}
+	return out, nil
+}
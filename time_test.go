@@ -0,0 +1,144 @@
+package sham
+
+import (
+	"math/rand"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestParseTimeBounds(t *testing.T) {
+	now := time.Date(2021, 6, 15, 12, 0, 0, 0, time.UTC)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	tests := []struct {
+		bounds  string
+		loc     *time.Location
+		min     time.Time
+		max     time.Time
+		wantErr bool
+	}{
+		{bounds: "-30d..now", min: now.AddDate(0, 0, -30), max: now},
+		{bounds: "now-1y6mo..now+2h30m", min: time.Date(2019, 12, 15, 12, 0, 0, 0, time.UTC), max: now.Add(150 * time.Minute)},
+		{bounds: "2020-01-01 .. 2020-12-31T23:59:59", min: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), max: time.Date(2020, 12, 31, 23, 59, 59, 0, time.UTC)},
+		{bounds: "2020-01-01..2020-01-02T00:00:00+05:00", loc: berlin, min: time.Date(2020, 1, 1, 0, 0, 0, 0, berlin), max: time.Date(2020, 1, 1, 19, 0, 0, 0, time.UTC)},
+		{bounds: "+1w..+2w", min: now.AddDate(0, 0, 7), max: now.AddDate(0, 0, 14)},
+		{bounds: "now..-1d", wantErr: true},
+		{bounds: "yesterday..now", wantErr: true},
+		{bounds: "-3x..now", wantErr: true},
+		{bounds: "-..now", wantErr: true},
+		{bounds: "now", wantErr: true},
+	}
+	for _, tt := range tests {
+		loc := tt.loc
+		if loc == nil {
+			loc = time.UTC
+		}
+
+		min, max, err := parseTimeBounds(tt.bounds, now, loc)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseTimeBounds(%q) error = %v, wantErr %v", tt.bounds, err, tt.wantErr)
+			continue
+		}
+		if !min.Equal(tt.min) || !max.Equal(tt.max) {
+			t.Errorf("parseTimeBounds(%q) = %v, %v, want %v, %v", tt.bounds, min, max, tt.min, tt.max)
+		}
+	}
+}
+
+func TestParseDurationBounds(t *testing.T) {
+	tests := []struct {
+		bounds   string
+		min, max time.Duration
+		wantErr  bool
+	}{
+		{bounds: "1s..2h30m", min: time.Second, max: 150 * time.Minute},
+		{bounds: "500ms..1d", min: 500 * time.Millisecond, max: 24 * time.Hour},
+		{bounds: "1y..2y", wantErr: true},
+		{bounds: "-1s..1s", wantErr: true},
+		{bounds: "2h..1h", wantErr: true},
+	}
+	for _, tt := range tests {
+		min, max, err := parseDurationBounds(tt.bounds)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseDurationBounds(%q) error = %v, wantErr %v", tt.bounds, err, tt.wantErr)
+			continue
+		}
+		if min != tt.min || max != tt.max {
+			t.Errorf("parseDurationBounds(%q) = %v, %v, want %v, %v", tt.bounds, min, max, tt.min, tt.max)
+		}
+	}
+}
+
+func TestFormatISO8601(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{d: 0, want: "PT0S"},
+		{d: 90 * time.Minute, want: "PT1H30M"},
+		{d: 26*time.Hour + 3*time.Second, want: "PT26H3S"},
+		{d: 1500 * time.Millisecond, want: "PT1.5S"},
+		{d: -time.Minute, want: "-PT1M"},
+	}
+	for _, tt := range tests {
+		if got := formatISO8601(tt.d); got != tt.want {
+			t.Errorf("formatISO8601(%v) = %s, want %s", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestTimeGenerators(t *testing.T) {
+	tests := []struct {
+		schema string
+		want   *regexp.Regexp
+	}{
+		{schema: `date`, want: regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)},
+		{schema: `date("2020-02-01..2020-02-29", "%d/%m/%Y")`, want: regexp.MustCompile(`^[0-2]\d/02/2020$`)},
+		{schema: `datetime("2020-01-01..2020-01-01T01:00:00Z", "", "Asia/Tokyo")`, want: regexp.MustCompile(`^2020-01-01T(0\d:\d{2}:\d{2}|10:00:00)\+09:00$`)},
+		{schema: `datetime("2020-01-01..2020-01-01", "Kitchen")`, want: regexp.MustCompile(`^12:00AM$`)},
+		{schema: `time("09:00..09:59")`, want: regexp.MustCompile(`^09:[0-5]\d:[0-5]\d$`)},
+		{schema: `duration("1m..59m", "iso8601")`, want: regexp.MustCompile(`^PT\d+M(\d+S)?$`)},
+		{schema: `past("2006")`, want: regexp.MustCompile(`^\d{4}$`)},
+	}
+	for _, tt := range tests {
+		s, err := NewDefaultParser([]byte(tt.schema)).Parse()
+		if err != nil {
+			t.Fatalf("Parse(%s) error = %v", tt.schema, err)
+		}
+
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 100; i++ {
+			if v, ok := s.GenerateRand(r).(string); !ok || !tt.want.MatchString(v) {
+				t.Fatalf("%s generated %v", tt.schema, v)
+			}
+		}
+	}
+}
+
+func TestUnixGenerators(t *testing.T) {
+	tests := []struct {
+		schema   string
+		min, max int
+	}{
+		{schema: `unixTime("2020-01-01..2020-01-02")`, min: 1577836800, max: 1577923200},
+		{schema: `unixMillis("2020-01-01..2020-01-01T00:00:01Z")`, min: 1577836800000, max: 1577836801000},
+		{schema: `duration("1s..10s", "milliseconds")`, min: 1000, max: 10000},
+	}
+	for _, tt := range tests {
+		s, err := NewDefaultParser([]byte(tt.schema)).Parse()
+		if err != nil {
+			t.Fatalf("Parse(%s) error = %v", tt.schema, err)
+		}
+
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 100; i++ {
+			if v, ok := s.GenerateRand(r).(int); !ok || v < tt.min || v > tt.max {
+				t.Fatalf("%s generated %v", tt.schema, v)
+			}
+		}
+	}
+}
@@ -17,6 +17,9 @@ const (
 	TokRParen
 	TokColon
 	TokComma
+	TokQuestion
+	TokPipe
+	TokAt
 
 	TokString
 	TokFString
@@ -41,6 +44,9 @@ var tokenStrings = map[TokenType]string{
 	TokRParen:   ")",
 	TokColon:    ":",
 	TokComma:    ",",
+	TokQuestion: "?",
+	TokPipe:     "|",
+	TokAt:       "@",
 	TokString:   "<STRING>",
 	TokFString:  "<F STRING>",
 	TokRegex:    "<REGEX>",
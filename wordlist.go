@@ -0,0 +1,153 @@
+package sham
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// wordList is a terminal generator choosing among a fixed list of strings,
+// either uniformly or in proportion to their weights.
+type wordList struct {
+	words []string
+	// cumulative holds the running total of the weights, and is nil when the
+	// words are chosen uniformly.
+	cumulative []float64
+}
+
+// NewWordList returns a terminal generator producing one of the given words.
+// If weights is nil, every word is equally likely. Otherwise weights holds a
+// non-negative weight for each word, and words are chosen in proportion to
+// their weights.
+func NewWordList(words []string, weights []float64) (Generator, error) {
+	if len(words) == 0 {
+		return nil, errors.New("word list is empty")
+	}
+	if weights == nil {
+		return wordList{words: words}, nil
+	} else if len(weights) != len(words) {
+		return nil, fmt.Errorf("expected %d weights, got %d", len(words), len(weights))
+	}
+
+	cumulative := make([]float64, len(weights))
+	total := 0.0
+	for i, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return nil, fmt.Errorf("invalid weight %v for %q", w, words[i])
+		}
+		total += w
+		cumulative[i] = total
+	}
+	if total == 0 {
+		return nil, errors.New("word list weights sum to zero")
+	}
+
+	return wordList{words: words, cumulative: cumulative}, nil
+}
+
+func (w wordList) Generate() interface{} { return w.GenerateRand(globalRand) }
+
+func (w wordList) GenerateRand(r *rand.Rand) interface{} {
+	if w.cumulative == nil {
+		return w.words[r.Intn(len(w.words))]
+	}
+
+	x := r.Float64() * w.cumulative[len(w.cumulative)-1]
+	i := sort.Search(len(w.cumulative), func(i int) bool { return w.cumulative[i] > x })
+	if i == len(w.words) {
+		i--
+	}
+	return w.words[i]
+}
+
+// LoadWordList reads a word list from a file and returns a terminal generator
+// producing its words. Files ending in .csv hold a word in the first column
+// and, optionally, its weight in the second. A first row whose weight is not a
+// number is treated as a header and skipped, and rows without a weight have a
+// weight of 1. Other files hold one word per line, chosen uniformly. In both
+// forms, surrounding whitespace is trimmed and empty words are skipped.
+func LoadWordList(path string) (Generator, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var g Generator
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		g, err = readCSVWordList(f)
+	} else {
+		g, err = readWordList(f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return g, nil
+}
+
+func readWordList(r io.Reader) (Generator, error) {
+	var words []string
+
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		if w := strings.TrimSpace(s.Text()); w != "" {
+			words = append(words, w)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return NewWordList(words, nil)
+}
+
+func readCSVWordList(r io.Reader) (Generator, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	var words []string
+	var weights []float64
+	weighted := false
+	for row := 1; ; row++ {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		w := strings.TrimSpace(rec[0])
+		if w == "" {
+			continue
+		}
+
+		weight := 1.0
+		if len(rec) > 1 && strings.TrimSpace(rec[1]) != "" {
+			weight, err = strconv.ParseFloat(strings.TrimSpace(rec[1]), 64)
+			if err != nil && row == 1 {
+				continue
+			} else if err != nil {
+				return nil, fmt.Errorf("row %d: invalid weight %q", row, rec[1])
+			}
+			weighted = true
+		}
+
+		words = append(words, w)
+		weights = append(weights, weight)
+	}
+
+	if !weighted {
+		weights = nil
+	}
+	return NewWordList(words, weights)
+}
@@ -0,0 +1,149 @@
+package sham
+
+import (
+	"io/ioutil"
+	"math/rand"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestLoadWordList(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    map[string]bool
+		wantErr string
+	}{
+		{
+			name:    "Lines",
+			file:    "products.txt",
+			content: "Widget\r\n\n  Gizmo Pro \nThing\n",
+			want:    map[string]bool{"Widget": true, "Gizmo Pro": true, "Thing": true},
+		},
+		{
+			name:    "CSV without weights",
+			file:    "skus.csv",
+			content: "A-1\n\"B-2, large\"\n",
+			want:    map[string]bool{"A-1": true, "B-2, large": true},
+		},
+		{
+			name:    "CSV with header and weights",
+			file:    "skus.CSV",
+			content: "sku,weight\nA-1, 3\nB-2,0\nC-3\n",
+			want:    map[string]bool{"A-1": true, "C-3": true},
+		},
+		{
+			name:    "Empty",
+			file:    "empty.txt",
+			content: "\n \n",
+			wantErr: "word list is empty",
+		},
+		{
+			name:    "Invalid weight",
+			file:    "bad.csv",
+			content: "A-1,1\nB-2,heavy\n",
+			wantErr: `row 2: invalid weight "heavy"`,
+		},
+		{
+			name:    "Negative weight",
+			file:    "negative.csv",
+			content: "A-1,-1\n",
+			wantErr: `invalid weight -1 for "A-1"`,
+		},
+		{
+			name:    "Zero weights",
+			file:    "zero.csv",
+			content: "A-1,0\n",
+			wantErr: "word list weights sum to zero",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := ioutil.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			g, err := LoadWordList(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.HasSuffix(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadWordList() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("LoadWordList() error = %v", err)
+			}
+
+			r := rand.New(rand.NewSource(1))
+			seen := make(map[string]bool)
+			for i := 0; i < 200; i++ {
+				v := generateRand(g, r).(string)
+				if !tt.want[v] {
+					t.Fatalf("GenerateRand() = %q", v)
+				}
+				seen[v] = true
+			}
+			if len(seen) != len(tt.want) {
+				t.Errorf("GenerateRand() produced %v, want all of %v", seen, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewWordList_Weights(t *testing.T) {
+	g, err := NewWordList([]string{"rare", "common"}, []float64{1, 9})
+	if err != nil {
+		t.Fatalf("NewWordList() error = %v", err)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	common := 0
+	for i := 0; i < 10000; i++ {
+		if generateRand(g, r) == "common" {
+			common++
+		}
+	}
+	if common < 8800 || common > 9200 {
+		t.Errorf("GenerateRand() chose the common word %d times in 10000, want about 9000", common)
+	}
+
+	if v := generateRand(g, rand.New(NewByteSource(nil))); v != "rare" {
+		t.Errorf("GenerateRand() with exhausted bytes = %v, want the first word", v)
+	}
+}
+
+func TestParser_RegisterWordList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "teams.txt")
+	if err := ioutil.WriteFile(path, []byte("Platform\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := NewDefaultParser([]byte("`{team} team, call {phoneNumber}`"))
+	p.Locale = "fr_FR"
+	if err := p.RegisterWordList("team", path); err != nil {
+		t.Fatalf("RegisterWordList() error = %v", err)
+	}
+	if err := p.RegisterWordList("null", path); err == nil {
+		t.Errorf("RegisterWordList() with a keyword as the name, want error")
+	}
+
+	s, err := p.Parse()
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := regexp.MustCompile(`^Platform team, call (0[1-9]|\+33 [1-9])( \d{2}){4}$`)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		if v := s.GenerateRand(r).(string); !want.MatchString(v) {
+			t.Fatalf("GenerateRand() = %q", v)
+		}
+	}
+
+	if _, ok := NewDefaultParser(nil).TerminalGenerators["team"]; ok {
+		t.Errorf("RegisterWordList() leaked the generator into other parsers")
+	}
+}